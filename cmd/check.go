@@ -23,7 +23,9 @@ import (
 	"github.com/turbot/steampipe/control/controlhooks"
 	"github.com/turbot/steampipe/display"
 	"github.com/turbot/steampipe/statushooks"
+	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/utils"
+	"github.com/turbot/steampipe/webhooks"
 	"github.com/turbot/steampipe/workspace"
 )
 
@@ -65,7 +67,9 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddStringSliceFlag(constants.ArgSearchPath, "", nil, "Set a custom search_path for the steampipe user for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, "", nil, "Set a prefix to the current search path for a check session (comma-separated)").
 		AddStringFlag(constants.ArgTheme, "", "dark", "Set the output theme for 'text' output: light, dark or plain").
-		AddStringSliceFlag(constants.ArgExport, "", nil, "Export output to files in various output formats: csv, html, json, md, nunit3 or asff(json)").
+		AddStringSliceFlag(constants.ArgExport, "", nil, "Export output to files in various output formats: csv, html, json, md, nunit3, junit, sarif or asff(json)").
+		AddStringFlag(constants.ArgBaseline, "", "", "Compare results with a previous JSON export, marking each failure as new or existing").
+		AddStringFlag(constants.ArgFailOn, "", constants.FailOnAll, "Failures which set the exit code: all, or new (requires --baseline)").
 		AddBoolFlag(constants.ArgProgress, "", true, "Display control execution progress").
 		AddBoolFlag(constants.ArgDryRun, "", false, "Show which controls will be run without running them").
 		AddStringSliceFlag(constants.ArgTag, "", nil, "Filter controls based on their tag values ('--tag key=value')").
@@ -116,6 +120,10 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// load the baseline, if one was provided
+	baseline, err := getBaseline()
+	utils.FailOnError(err)
+
 	// if progress is disabled, update context to contain a null status hooks object
 	if !viper.GetBool(constants.ArgProgress) {
 		statushooks.DisableStatusHooks(ctx)
@@ -130,6 +138,11 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 	// pull out useful properties
 	workspace := initData.Workspace
 	client := initData.Client
+	// warn about any suppressions which have expired (these are no longer applied)
+	showExpiredSuppressionWarnings(workspace)
+	// create the notifier for any configured webhooks
+	notifier, err := webhooks.NewNotifier(steampipeconfig.GlobalConfig.Webhooks)
+	utils.FailOnError(err)
 	failures := 0
 	var exportErrors []error
 	exportErrorsLock := sync.Mutex{}
@@ -152,11 +165,16 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		// create the execution tree
 		executionTree, err := controlexecute.NewExecutionTree(ctx, workspace, client, arg)
 		utils.FailOnErrorWithMessage(err, "failed to resolve controls from argument")
+		executionTree.Baseline = baseline
 
 		// execute controls synchronously (execute returns the number of failures)
 		failures += executionTree.Execute(ctx)
+		notifyCheckComplete(ctx, notifier, arg, executionTree)
 		err = displayControlResults(ctx, executionTree)
 		utils.FailOnError(err)
+		if shouldPrintBaselineSummary(executionTree) {
+			printBaselineSummary(executionTree.BaselineSummary)
+		}
 
 		if len(exportTargets) > 0 {
 			d := control.ExportData{
@@ -198,6 +216,18 @@ func createCheckContext(ctx context.Context) context.Context {
 	return controlhooks.AddControlHooksToContext(ctx, controlHooks)
 }
 
+// send a check event for the execution of the arg to the configured webhooks
+// the event summary contains the control status counts of the execution
+func notifyCheckComplete(ctx context.Context, notifier *webhooks.Notifier, arg string, executionTree *controlexecute.ExecutionTree) {
+	status, errorString := "complete", ""
+	if ctx.Err() != nil {
+		status, errorString = "error", ctx.Err().Error()
+	}
+	event := webhooks.NewCheckEvent(arg, status, errorString, executionTree.Root.Summary.Status.Counts())
+	// use a background context so the event is still sent if the execution was cancelled
+	notifier.Notify(context.Background(), event)
+}
+
 func validateArgs(ctx context.Context, cmd *cobra.Command, args []string) bool {
 	if len(args) == 0 {
 		fmt.Println()
@@ -253,6 +283,53 @@ func printTiming(args []string, durations []time.Duration) {
 	display.ShowWrappedTable(headers, rows, false)
 }
 
+// getBaseline loads the baseline passed using --baseline (if any) and validates the --fail-on arg
+func getBaseline() (*controlexecute.Baseline, error) {
+	failOn := viper.GetString(constants.ArgFailOn)
+	if !helpers.StringSliceContains([]string{constants.FailOnAll, constants.FailOnNew}, failOn) {
+		return nil, fmt.Errorf("invalid value for --%s: '%s' - valid values are %s and %s", constants.ArgFailOn, failOn, constants.FailOnAll, constants.FailOnNew)
+	}
+
+	baselinePath := viper.GetString(constants.ArgBaseline)
+	if baselinePath == "" {
+		if failOn == constants.FailOnNew {
+			return nil, fmt.Errorf("--%s %s requires a baseline to be passed using --%s", constants.ArgFailOn, constants.FailOnNew, constants.ArgBaseline)
+		}
+		return nil, nil
+	}
+	return controlexecute.LoadBaseline(baselinePath)
+}
+
+func showExpiredSuppressionWarnings(w *workspace.Workspace) {
+	expired := w.GetExpiredSuppressions(time.Now())
+	for _, suppression := range expired {
+		warning := fmt.Sprintf("%s for control '%s' and resource '%s' expired on %s", suppression, suppression.Control, suppression.Resource, suppression.Expires.Format("2006-01-02"))
+		// do not write warnings into machine readable output
+		if shouldPrintCheckMessages() {
+			utils.ShowWarning(warning)
+		} else {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+}
+
+func printBaselineSummary(summary *controlexecute.BaselineSummary) {
+	fmt.Printf("\nBaseline: %d new, %d existing, %d resolved\n", summary.New, summary.Existing, summary.Resolved)
+	for _, row := range summary.ResolvedRows {
+		fmt.Printf("  resolved: %s\n", row.String())
+	}
+}
+
+func shouldPrintBaselineSummary(executionTree *controlexecute.ExecutionTree) bool {
+	return executionTree.BaselineSummary != nil && shouldPrintCheckMessages()
+}
+
+// messages are only printed for text output, to avoid corrupting machine readable output
+func shouldPrintCheckMessages() bool {
+	outputFormat := viper.GetString(constants.ArgOutput)
+	return outputFormat == constants.CheckOutputFormatText || outputFormat == constants.CheckOutputFormatBrief
+}
+
 func shouldPrintTiming() bool {
 	outputFormat := viper.GetString(constants.ArgOutput)
 
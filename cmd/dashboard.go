@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/turbot/steampipe/statushooks"
 
@@ -14,27 +16,60 @@ import (
 	"github.com/turbot/steampipe/contexthelpers"
 	"github.com/turbot/steampipe/dashboard"
 	"github.com/turbot/steampipe/dashboard/dashboardassets"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardexport"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/dashboard/dashboardserver"
 	"github.com/turbot/steampipe/utils"
 )
 
 func dashboardCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:              "dashboard",
+		Use:              "dashboard [flags] [dashboard]",
 		TraverseChildren: true,
 		Args:             cobra.ArbitraryArgs,
 		Run:              runDashboardCmd,
 		Short:            "Start the local dashboard UI",
 		Long: `Starts a local web server that enables real-time development of dashboards within the current mod.
 
-The current mod is the working directory, or the directory specified by the --workspace-chdir flag.`,
+The current mod is the working directory, or the directory specified by the --workspace-chdir flag.
+
+To run a single dashboard without starting the server and save the results as a JSON snapshot, pass the
+dashboard name and the --snapshot flag:
+
+  steampipe dashboard dashboard.cost_report --snapshot cost_report.json
+
+To export a single dashboard as a self-contained HTML file which can be viewed without the server, pass the
+--export flag:
+
+  steampipe dashboard dashboard.cost_report --export cost_report.html
+
+While the server is running, dashboards and benchmarks may be run on a schedule by adding 'schedule' blocks
+to the Steampipe config (or workspace.spc). The results of each run are saved to ~/.steampipe/dashboard/results:
+
+  schedule "nightly" {
+    cron    = "0 2 * * *"
+    targets = ["dashboard.cost_report", "benchmark.cis_v140"]
+  }
+
+Dashboard completion and errors may be sent to a webhook by adding 'webhook' blocks to the Steampipe config.
+Valid events are dashboard_complete, dashboard_error, leaf_error, check_complete and check_error
+(check_error is sent when a benchmark run fails, or when any of its controls are in alarm or error):
+
+  webhook "slack" {
+    url    = "https://hooks.slack.com/services/..."
+    events = ["dashboard_error", "check_error"]
+    body   = "{\"text\": {{ printf \"%s failed: %s\" .Name .Error | json }}}"
+  }`,
 	}
 
 	cmdconfig.OnCmd(cmd).
 		AddBoolFlag(constants.ArgHelp, "h", false, "Help for dashboard").
 		AddStringFlag(constants.ArgDashboardServerListen, "", string(dashboardserver.ListenTypeLocal), "Accept connections from: local (localhost only) or network (open)").
 		AddIntFlag(constants.ArgDashboardServerPort, "", constants.DashboardServerDefaultPort, "Dashboard server port.").
-		AddBoolFlag(constants.ArgModInstall, "", true, "Specify whether to install mod dependencies before running the dashboard")
+		AddBoolFlag(constants.ArgModInstall, "", true, "Specify whether to install mod dependencies before running the dashboard").
+		AddStringFlag(constants.ArgSnapshot, "", "", "Run the given dashboard without starting the server and write the results to a JSON snapshot file").
+		AddStringFlag(constants.ArgExport, "", "", "Run the given dashboard without starting the server and export the results to a static HTML file")
 	return cmd
 }
 
@@ -54,6 +89,12 @@ func runDashboardCmd(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	// if a snapshot or export file was passed, run the dashboard without the server
+	if viper.IsSet(constants.ArgSnapshot) || viper.IsSet(constants.ArgExport) {
+		runDashboardSnapshot(dashboardCtx, args)
+		return
+	}
+
 	serverPort := dashboardserver.ListenPort(viper.GetInt(constants.ArgDashboardServerPort))
 	utils.FailOnError(serverPort.IsValid())
 
@@ -85,6 +126,57 @@ func runDashboardCmd(cmd *cobra.Command, args []string) {
 	server.Shutdown(dashboardCtx)
 }
 
+// run a single dashboard and write the results to a JSON snapshot file and/or a static HTML file
+func runDashboardSnapshot(ctx context.Context, args []string) {
+	if len(args) != 1 {
+		utils.FailOnError(fmt.Errorf("--%s and --%s require a single dashboard name argument", constants.ArgSnapshot, constants.ArgExport))
+	}
+	snapshotFile := viper.GetString(constants.ArgSnapshot)
+	if viper.IsSet(constants.ArgSnapshot) && snapshotFile == "" {
+		utils.FailOnError(fmt.Errorf("--%s requires a file name", constants.ArgSnapshot))
+	}
+	exportFile := viper.GetString(constants.ArgExport)
+	if viper.IsSet(constants.ArgExport) && !dashboardexport.IsSupportedExportFile(exportFile) {
+		utils.FailOnError(fmt.Errorf("--%s requires an HTML file name, e.g. report.html", constants.ArgExport))
+	}
+
+	// load the workspace
+	w, err := loadWorkspacePromptingForVariables(ctx)
+	utils.FailOnErrorWithMessage(err, "failed to load workspace")
+
+	initData := dashboard.NewInitData(ctx, w)
+	defer func() {
+		if initData.Client != nil {
+			initData.Client.Close(ctx)
+		}
+		initData.Workspace.Close()
+	}()
+	if shouldExit := handleDashboardInitResult(ctx, initData); shouldExit {
+		return
+	}
+
+	snapshot, err := dashboardexecute.ExecuteSnapshot(ctx, args[0], initData.Workspace, initData.Client)
+	utils.FailOnError(err)
+
+	var outputFiles []string
+	if snapshotFile != "" {
+		err = dashboardexecute.WriteSnapshot(snapshot, snapshotFile)
+		utils.FailOnErrorWithMessage(err, "failed to write snapshot")
+		outputFiles = append(outputFiles, snapshotFile)
+	}
+	if exportFile != "" {
+		err = dashboardexport.ExportHTML(snapshot, exportFile)
+		utils.FailOnErrorWithMessage(err, "failed to export dashboard")
+		outputFiles = append(outputFiles, exportFile)
+	}
+
+	// the output is written even if the dashboard failed - so it includes the errors
+	if snapshot.Status == dashboardinterfaces.DashboardRunError {
+		utils.ShowWarning(fmt.Sprintf("dashboard %s completed with errors - see %s", snapshot.Dashboard, strings.Join(outputFiles, ", ")))
+		exitCode = 1
+	}
+}
+
 func handleDashboardInitResult(ctx context.Context, initData *dashboard.InitData) bool {
 	// if there is an error or cancellation we bomb out
 	// check for the various kinds of failures
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,6 +16,7 @@ import (
 	"github.com/turbot/steampipe/ociinstaller"
 	"github.com/turbot/steampipe/ociinstaller/versionfile"
 	"github.com/turbot/steampipe/plugin"
+	"github.com/turbot/steampipe/pluginmanager"
 	"github.com/turbot/steampipe/statefile"
 	"github.com/turbot/steampipe/statushooks"
 	"github.com/turbot/steampipe/steampipeconfig"
@@ -44,6 +46,9 @@ Examples:
   # List installed plugins
   steampipe plugin list
 
+  # Show the running plugin processes
+  steampipe plugin status
+
   # Uninstall a plugin
   steampipe plugin uninstall aws`,
 	}
@@ -52,6 +57,7 @@ Examples:
 	cmd.AddCommand(pluginListCmd())
 	cmd.AddCommand(pluginUninstallCmd())
 	cmd.AddCommand(pluginUpdateCmd())
+	cmd.AddCommand(pluginStatusCmd())
 	cmd.Flags().BoolP(constants.ArgHelp, "h", false, "Help for plugin")
 
 	return cmd
@@ -77,7 +83,24 @@ Examples:
   steampipe plugin install aws
 
   # Install a specific plugin version
-  steampipe plugin install turbot/azure@0.1.0`,
+  steampipe plugin install turbot/azure@0.1.0
+
+  # Install a plugin from a private registry
+  steampipe plugin install registry.internal/org/plugin:1.2
+
+  # Install a plugin from a local archive (installed as local/my-plugin)
+  steampipe plugin install ./my-plugin.tar.gz
+
+Credentials for private registries are read from the docker config file
+(~/.docker/config.json, including credential helpers), or may be set using
+the STEAMPIPE_REGISTRY_USERNAME and STEAMPIPE_REGISTRY_PASSWORD env vars.
+The env var credentials are only sent to the registry host set in the
+STEAMPIPE_REGISTRY_HOST env var, e.g. registry.internal.
+
+A local archive is a gzipped tar file containing the plugin binary (with the
+extension .plugin) and optionally 'docs' and 'config' folders. The plugin is
+installed as local/<name>, where the name is the archive file name without
+the extension and any 'steampipe-plugin-' prefix.`,
 	}
 
 	cmdconfig.
@@ -145,6 +168,31 @@ Examples:
 	return cmd
 }
 
+// Show running plugin processes
+func pluginStatusCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "status",
+		Args:  cobra.NoArgs,
+		Run:   runPluginStatusCmd,
+		Short: "Show the status of running plugin processes",
+		Long: `Show the status of running plugin processes.
+
+List the plugin processes started by the Steampipe plugin manager, with the
+connection each serves, the process id, start time, restart count and the
+memory and CPU usage of the process.
+
+Example:
+
+  # Show running plugin processes
+  steampipe plugin status`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for plugin status")
+
+	return cmd
+}
+
 // Uninstall a plugin
 func pluginUninstallCmd() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -189,7 +237,8 @@ func runPluginInstallCmd(cmd *cobra.Command, args []string) {
 
 	// args to 'plugin install' -- one or more plugins to install
 	// plugin names can be simple names ('aws') for "standard" plugins,
-	// or full refs to the OCI image (us-docker.pkg.dev/steampipe/plugin/turbot/aws:1.0.0)
+	// full refs to the OCI image (us-docker.pkg.dev/steampipe/plugin/turbot/aws:1.0.0)
+	// or paths to local plugin archives (./my-plugin.tar.gz)
 	plugins := append([]string{}, args...)
 	installReports := make([]display.InstallReport, 0, len(plugins))
 
@@ -209,8 +258,10 @@ func runPluginInstallCmd(cmd *cobra.Command, args []string) {
 	statusSpinner := statushooks.NewStatusSpinner()
 
 	for _, p := range plugins {
+		// local plugin archives are always installed, replacing any previous installation
+		isPluginArchive := ociinstaller.IsPluginArchive(p)
 		isPluginExists, _ := plugin.Exists(p)
-		if isPluginExists {
+		if isPluginExists && !isPluginArchive {
 			installReports = append(installReports, display.InstallReport{
 				Plugin:         p,
 				Skipped:        true,
@@ -236,13 +287,26 @@ func runPluginInstallCmd(cmd *cobra.Command, args []string) {
 			})
 			continue
 		}
+		if isPluginArchive {
+			// report the name the plugin was installed as - this is the name used in connection config
+			installReports = append(installReports, display.InstallReport{
+				Skipped:        false,
+				Plugin:         image.ImageRef,
+				IsUpdateReport: false,
+			})
+			continue
+		}
 		versionString := ""
 		if image.Config.Plugin.Version != "" {
 			versionString = " v" + image.Config.Plugin.Version
 		}
-		org := image.Config.Plugin.Organization
-		name := image.Config.Plugin.Name
-		docURL := fmt.Sprintf("https://hub.steampipe.io/plugins/%s/%s", org, name)
+		// only plugins from the Steampipe registry have hub documentation
+		docURL := ""
+		if strings.HasPrefix(ociinstaller.NewSteampipeImageRef(p).DisplayImageRef(), ociinstaller.DefaultImageRepoDisplayURL) {
+			org := image.Config.Plugin.Organization
+			name := image.Config.Plugin.Name
+			docURL = fmt.Sprintf("https://hub.steampipe.io/plugins/%s/%s", org, name)
+		}
 		installReports = append(installReports, display.InstallReport{
 			Skipped:        false,
 			Plugin:         p,
@@ -493,6 +557,52 @@ func runPluginListCmd(cmd *cobra.Command, args []string) {
 	display.ShowWrappedTable(headers, rows, false)
 }
 
+func runPluginStatusCmd(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	utils.LogTime("runPluginStatusCmd status")
+	defer func() {
+		utils.LogTime("runPluginStatusCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(ctx, helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	// do not start the plugin manager if it is not running
+	status, err := pluginmanager.GetStatus()
+	if err != nil {
+		utils.ShowErrorWithMessage(ctx, err, "failed to get plugin status")
+		exitCode = 3
+		return
+	}
+	if status == nil {
+		fmt.Println("Plugin manager is not running.")
+		return
+	}
+
+	headers := []string{"Connection", "Plugin", "Status", "PID", "Started", "Restarts", "Memory", "CPU", "Last Error"}
+	rows := [][]string{}
+	for _, p := range status.Plugins {
+		// degraded and restarting plugins have no process
+		if p.Pid == 0 {
+			rows = append(rows, []string{p.Connection, p.Plugin, p.Status, "", "", fmt.Sprintf("%d", p.RestartCount), "", "", p.LastError})
+			continue
+		}
+		rows = append(rows, []string{
+			p.Connection,
+			p.Plugin,
+			p.Status,
+			fmt.Sprintf("%d", p.Pid),
+			time.Unix(p.StartTime, 0).Format(time.RFC3339),
+			fmt.Sprintf("%d", p.RestartCount),
+			fmt.Sprintf("%.1f MB", float64(p.MemoryBytes)/(1024*1024)),
+			fmt.Sprintf("%.1f%%", p.CpuPercent),
+			p.LastError,
+		})
+	}
+	display.ShowWrappedTable(headers, rows, false)
+}
+
 func runPluginUninstallCmd(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	utils.LogTime("runPluginUninstallCmd uninstall")
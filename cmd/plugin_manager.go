@@ -43,7 +43,7 @@ func runPluginManagerCmd(cmd *cobra.Command, args []string) {
 		log.Printf("[WARN] failed to load connection config: %s", err.Error())
 		os.Exit(1)
 	}
-	configMap := connectionwatcher.NewConnectionConfigMap(steampipeConfig.Connections)
+	configMap := connectionwatcher.NewConnectionConfigMap(steampipeConfig)
 	log.Printf("[TRACE] loaded config map")
 
 	pluginManager := pluginmanager.NewPluginManager(configMap, logger)
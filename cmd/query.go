@@ -41,7 +41,17 @@ Examples:
   steampipe query
 
   # Run a specific query directly
-  steampipe query "select * from cloud"`,
+  steampipe query "select * from cloud"
+
+  # Run a named query and export the results to a parquet file
+  steampipe query query.my_query --export results.parquet
+
+  # Run a named query, passing a value for one of its parameters
+  steampipe query query.my_query --arg region=us-east-1
+
+  # Show how the results of a query have changed since a previous run
+  steampipe query query.my_query --output json > previous.json
+  steampipe query query.my_query --diff-against previous.json --diff-key arn`,
 
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			workspace, err := workspace.LoadResourceNames(viper.GetString(constants.ArgWorkspaceChDir))
@@ -65,12 +75,18 @@ Examples:
 		AddBoolFlag(constants.ArgHelp, "h", false, "Help for query").
 		AddBoolFlag(constants.ArgHeader, "", true, "Include column headers csv and table output").
 		AddStringFlag(constants.ArgSeparator, "", ",", "Separator string for csv output").
-		AddStringFlag(constants.ArgOutput, "", "table", "Output format: line, csv, json or table").
+		AddStringFlag(constants.ArgOutput, "", "table", "Output format: line, csv, json, table, md, html, sql, parquet or arrow").
+		AddStringSliceFlag(constants.ArgExport, "", nil, "Export query results to files: csv, json, md, html, sql, parquet or arrow (the format is inferred from the file extension)").
+		AddStringFlag(constants.ArgSQLTable, "", "query_result", "Target table name for the INSERT statements of sql output").
+		AddStringFlag(constants.ArgDiffAgainst, "", "", "Show the differences of the query results from a previous result, saved using --output json").
+		AddStringSliceFlag(constants.ArgDiffKey, "", nil, "Columns used to match rows when showing differences (comma-separated) - if not set, the full row is used").
 		AddBoolFlag(constants.ArgTimer, "", false, "Turn on the timer which reports query time.").
 		AddBoolFlag(constants.ArgWatch, "", true, "Watch SQL files in the current workspace (works only in interactive mode)").
 		AddStringSliceFlag(constants.ArgSearchPath, "", nil, "Set a custom search_path for the steampipe user for a query session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, "", nil, "Set a prefix to the current search path for a query session (comma-separated)").
 		AddStringSliceFlag(constants.ArgVarFile, "", nil, "Specify a file containing variable values").
+		// NOTE: use StringArrayFlag for ArgArg, as for ArgVariable, so values may contain commas
+		AddStringArrayFlag(constants.ArgArg, "", nil, "Specify the value of a named query parameter, in the form name=value").
 		// NOTE: use StringArrayFlag for ArgVariable, not StringSliceFlag
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
@@ -100,6 +116,14 @@ func runQueryCmd(cmd *cobra.Command, args []string) {
 	// set config to indicate whether we are running an interactive query
 	viper.Set(constants.ConfigKeyInteractive, interactiveMode)
 
+	if interactiveMode {
+		for _, batchArg := range []string{constants.ArgExport, constants.ArgArg} {
+			if len(viper.GetStringSlice(batchArg)) > 0 {
+				utils.FailOnError(fmt.Errorf("--%s is only supported when running queries in batch mode", batchArg))
+			}
+		}
+	}
+
 	// load the workspace
 	w, err := loadWorkspacePromptingForVariables(ctx)
 	utils.FailOnErrorWithMessage(err, "failed to load workspace")
@@ -26,8 +26,9 @@ func SetViperDefaults(configMap map[string]interface{}) {
 // for keys which do not have a corresponding command flag, we need a separate defaulting mechanism
 func setBaseDefaults() {
 	defaults := map[string]interface{}{
-		constants.ArgUpdateCheck: true,
-		constants.ArgInstallDir:  filepaths.DefaultInstallDir,
+		constants.ArgUpdateCheck:                    true,
+		constants.ArgInstallDir:                     filepaths.DefaultInstallDir,
+		constants.ArgDashboardServerResultRetention: constants.DashboardServerDefaultResultRetention,
 	}
 
 	for k, v := range defaults {
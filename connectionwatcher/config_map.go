@@ -2,17 +2,26 @@ package connectionwatcher
 
 import (
 	pb "github.com/turbot/steampipe/pluginmanager/grpc/proto"
-	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/steampipeconfig"
 )
 
-func NewConnectionConfigMap(connectionMap map[string]*modconfig.Connection) map[string]*pb.ConnectionConfig {
+func NewConnectionConfigMap(config *steampipeconfig.SteampipeConfig) map[string]*pb.ConnectionConfig {
 	configMap := make(map[string]*pb.ConnectionConfig)
-	for k, v := range connectionMap {
-		configMap[k] = &pb.ConnectionConfig{
+	for k, v := range config.Connections {
+		connectionConfig := &pb.ConnectionConfig{
 			Plugin:          v.Plugin,
 			PluginShortName: v.PluginShortName,
 			Config:          v.Config,
 		}
+		// set the plugin process options for the connection
+		pluginOptions := config.GetPluginOptions(k)
+		if pluginOptions.MemoryMaxMb != nil {
+			connectionConfig.MemoryMaxMb = int64(*pluginOptions.MemoryMaxMb)
+		}
+		if pluginOptions.IdleTimeout != nil {
+			connectionConfig.IdleTimeout = int64(*pluginOptions.IdleTimeout)
+		}
+		configMap[k] = connectionConfig
 	}
 	return configMap
 }
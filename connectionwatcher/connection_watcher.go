@@ -92,7 +92,7 @@ func (w *ConnectionWatcher) handleFileWatcherEvent(e []fsnotify.Event) {
 	log.Printf("[TRACE] calling onConnectionConfigChanged")
 	// convert config to format expected by plugin manager
 	// (plugin manager cannot reference steampipe config to avoid circular deps)
-	configMap := NewConnectionConfigMap(steampipeconfig.GlobalConfig.Connections)
+	configMap := NewConnectionConfigMap(steampipeconfig.GlobalConfig)
 	// call on changed callback
 	// (this calls pluginmanager.SetConnectionConfigMap)
 	w.onConnectionConfigChanged(configMap)
@@ -10,11 +10,14 @@ const (
 	ArgOn                    = "on"
 	ArgOff                   = "off"
 	ArgClear                 = "clear"
+	ArgSearch                = "search"
+	ArgRun                   = "run"
 	ArgDatabasePort          = "database-port"
 	ArgListenAddress         = "database-listen"
 	ArgServicePassword       = "database-password"
 	ArgDashboardServerListen = "dashboardserver-listen"
 	ArgDashboardServerPort   = "dashboardserver-port"
+	ArgSnapshot              = "snapshot"
 	ArgForeground            = "foreground"
 	ArgInvoker               = "invoker"
 	ArgUpdateCheck           = "update-check"
@@ -41,6 +44,30 @@ const (
 	ArgCheckDisplayWidth     = "check-display-width"
 	ArgPrune                 = "prune"
 	ArgModInstall            = "mod-install"
+	ArgSQLTable              = "sql-table"
+	ArgArg                   = "arg"
+	ArgDiffAgainst           = "diff-against"
+	ArgDiffKey               = "diff-key"
+	ArgBaseline              = "baseline"
+	ArgFailOn                = "fail-on"
+)
+
+// dashboard server args - these are set from the 'dashboard' options block
+const (
+	ArgDashboardServerTLS             = "dashboardserver-tls"
+	ArgDashboardServerTLSCertificate  = "dashboardserver-tls-certificate"
+	ArgDashboardServerTLSKey          = "dashboardserver-tls-key"
+	ArgDashboardServerAuthToken       = "dashboardserver-auth-token"
+	ArgDashboardServerAuthUsername    = "dashboardserver-auth-username"
+	ArgDashboardServerAuthPassword    = "dashboardserver-auth-password"
+	ArgDashboardServerCacheTTL        = "dashboardserver-cache-ttl"
+	ArgDashboardServerResultRetention = "dashboardserver-result-retention"
+)
+
+// values for the fail-on arg
+const (
+	FailOnAll = "all"
+	FailOnNew = "new"
 )
 
 /// metaquery mode arguments
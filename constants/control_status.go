@@ -6,4 +6,6 @@ const (
 	ControlSkip  = "skip"
 	ControlInfo  = "info"
 	ControlError = "error"
+	// a failing result which has been marked as an accepted risk by a suppression
+	ControlSuppressed = "suppressed"
 )
@@ -4,4 +4,7 @@ const (
 	DashboardServerDefaultPort = 9194
 	DashboardAssetsVersion     = "0.13.0-alpha.8"
 	DashboardAssetsImageRef    = "us-docker.pkg.dev/steampipe/steampipe/assets:" + DashboardAssetsVersion
+
+	// the default number of scheduled run results retained by the dashboard server
+	DashboardServerDefaultResultRetention = 100
 )
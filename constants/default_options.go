@@ -19,9 +19,22 @@ const DefaultConnectionConfigContent = `
 #   search_path =  ""     # comma-separated string
 # }
 
+# options "dashboard" {
+#   port             = 9194    # any valid, open port number
+#   listen           = "local" # local, network
+#   tls              = false   # true, false - if no certificate is set, the service certificate is used
+#   tls_certificate  = ""      # path to a PEM encoded certificate
+#   tls_key          = ""      # path to a PEM encoded private key
+#   auth_token       = ""      # token passed as a bearer token or 'token' query parameter
+#   auth_username    = ""      # basic auth username
+#   auth_password    = ""      # basic auth password
+#   cache_ttl        = 0       # expiration (TTL) in seconds of cached dashboard query results - 0 disables caching
+#   result_retention = 100     # number of scheduled run results to retain - 0 retains all results
+# }
+
 # options "terminal" {
 #   multi               = false   # true, false
-#   output              = "table" # json, csv, table, line
+#   output              = "table" # json, csv, table, line, md, html, sql
 #   header              = true    # true, false
 #   separator           = ","     # any single char
 #   timing              = false   # true, false
@@ -30,6 +43,11 @@ const DefaultConnectionConfigContent = `
 #   watch  			    =  true   # true, false
 # }
 
+# options "plugin" {
+#   memory_max_mb = 0 # memory limit of each plugin process in MB - 0 means no limit
+#   idle_timeout  = 0 # time in seconds after which an unused plugin process is stopped - 0 disables this
+# }
+
 # options "general" {
 #   update_check = true # true, false
 # }
@@ -11,4 +11,9 @@ const (
 
 	// NullString is the string which is displayed for null column values
 	NullString = "<null>"
+
+	// ColumnarBatchSize is the number of rows written in each arrow record batch
+	ColumnarBatchSize = 10000
+	// ParquetRowGroupSize is the (approximate) size in bytes at which a parquet row group is flushed
+	ParquetRowGroupSize = 32 * 1024 * 1024
 )
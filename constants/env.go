@@ -18,6 +18,12 @@ const (
 	EnvConnectionWatcher = "STEAMPIPE_CONNECTION_WATCHER"
 	EnvWorkspaceChDir    = "STEAMPIPE_WORKSPACE_CHDIR"
 
+	// EnvRegistryUsername and EnvRegistryPassword are the credentials used to install plugins from a private registry
+	// they are only sent to the registry host set in EnvRegistryHost
+	EnvRegistryUsername = "STEAMPIPE_REGISTRY_USERNAME"
+	EnvRegistryPassword = "STEAMPIPE_REGISTRY_PASSWORD"
+	EnvRegistryHost     = "STEAMPIPE_REGISTRY_HOST"
+
 	// EnvInputVarPrefix is the prefix for environment variables that represent values for input variables.
 	EnvInputVarPrefix = "SP_VAR_"
 )
@@ -4,4 +4,6 @@ package constants
 const (
 	HistoryFile = "history.json" // File to store historical data
 	HistorySize = 500            // Number of historical records to store
+	// HistoryDisplaySize is the number of records displayed by the .history metaquery when no search is specified
+	HistoryDisplaySize = 20
 )
@@ -20,6 +20,8 @@ const (
 	CmdSearchPath       = ".search_path"        // Set or show search-path
 	CmdSearchPathPrefix = ".search_path_prefix" // set search path prefix
 	CmdCache            = ".cache"              // cache control
+	CmdHistory          = ".history"            // list, search and re-run query history
+	CmdDiff             = ".diff"               // diff query results against a previous json result
 )
 
 // ArgFromMetaquery converts a metaquery of form '.header' into the config argument used to set the mode, i.e. 'header'
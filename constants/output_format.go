@@ -6,6 +6,13 @@ const (
 	OutputFormatJSON  = "json"
 	OutputFormatTable = "table"
 	OutputFormatLine  = "line"
+	// document query output formats
+	OutputFormatMarkdown = "md"
+	OutputFormatHTML     = "html"
+	OutputFormatSQL      = "sql"
+	// binary query output formats
+	OutputFormatParquet = "parquet"
+	OutputFormatArrow   = "arrow"
 
 	// check output format
 	CheckOutputFormatNone     = "none"
@@ -17,4 +24,6 @@ const (
 	CheckOutputFormatMarkdown = "md"
 	CheckOutputFormatNUnit3   = "nunit3"
 	CheckOutputFormatAsffJson = "json-asff"
+	CheckOutputFormatSarif    = "sarif"
+	CheckOutputFormatJUnit    = "junit"
 )
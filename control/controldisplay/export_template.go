@@ -6,9 +6,16 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/filepaths"
 )
 
+// the preferred template for extensions which match more than one built-in template
+// (used when an export filename is resolved by its extension)
+var preferredTemplateForExtension = map[string]string{
+	".xml": constants.CheckOutputFormatNUnit3,
+}
+
 type ExportTemplate struct {
 	TemplatePath                string
 	FormatName                  string
@@ -105,6 +112,11 @@ func findTemplateByFilename(export string, available []*ExportTemplate) (format
 			}
 			matchNames = append(matchNames, match.FormatName)
 		}
+		for _, match := range matchingTemplates {
+			if preferredTemplateForExtension[extension] == match.FormatName {
+				return match, nil
+			}
+		}
 		// there's ambiguity - we have more than one matching templates based on extension
 		return nil, fmt.Errorf("ambiguous templates found: %v", matchNames)
 	}
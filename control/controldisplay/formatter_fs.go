@@ -1,11 +1,16 @@
 package controldisplay
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/turbot/steampipe/filepaths"
 )
@@ -14,33 +19,140 @@ import (
 var builtinTemplateFS embed.FS
 
 // EnsureTemplates scans the '$STEAMPIPE_INSTALL_DIR/templates' directory and
-// copies over any missing templates as defined in the 'templates' package
+// copies over any missing or outdated templates as defined in the 'templates' package
 //
 // The name of the folder in the 'templates' package is used to identify
 // templates in '$STEAMPIPE_INSTALL_DIR/templates' - where it is expected
 // that a directory with the same name will exist. If said directory does
 // not exist, it is copied over from 'templates'
 //
+// A hash of each built-in template is recorded in the template version file - if the built-in
+// template has changed since it was installed, the installed template is overwritten, as long
+// as the installed files have not been modified. Modified templates (and templates installed before
+// the version file existed which do not match the current built-in version) are moved to the
+// template backup directory before being replaced, so no user customisations are lost
+//
 func EnsureTemplates() error {
 	log.Println("[TRACE] ensuring check export/output templates")
 	dirs, err := fs.ReadDir(builtinTemplateFS, "templates")
 	if err != nil {
 		return err
 	}
+	versionFile, err := loadTemplateVersionFile()
+	if err != nil {
+		return err
+	}
+	updated := false
 	for _, d := range dirs {
+		version, err := templateVersion(builtinTemplateFS, filepath.Join("templates", d.Name()))
+		if err != nil {
+			return err
+		}
 		targetDirectory := filepath.Join(filepaths.EnsureTemplateDir(), d.Name())
-		if _, err := os.Stat(targetDirectory); os.IsNotExist(err) {
+		installedVersion, err := templateVersion(os.DirFS(targetDirectory), ".")
+		recordedVersion := versionFile.Templates[d.Name()]
+		switch {
+		case os.IsNotExist(err):
 			log.Println("[TRACE] target directory does not exist - copying template")
-			if err := writeTemplate(d.Name(), targetDirectory); err != nil {
-				log.Println("[ERROR] error copying template", err)
+		case err != nil:
+			log.Println("[ERROR] error fetching directory information", err)
+			return err
+		case recordedVersion == version:
+			// the installed template is up to date
+			continue
+		case installedVersion == version:
+			// the installed files match the built-in template - just record the version
+			versionFile.Templates[d.Name()] = version
+			updated = true
+			continue
+		case recordedVersion != "" && installedVersion == recordedVersion:
+			log.Println("[TRACE] template has changed - overwriting", d.Name())
+		default:
+			// either the installed template has been modified, or we do not know which version was installed
+			backupDirectory, err := backupTemplate(d.Name(), targetDirectory)
+			if err != nil {
+				log.Println("[ERROR] error backing up template", err)
 				return err
 			}
-		} else if err != nil {
-			log.Println("[ERROR] error fetching directory information", err)
+			log.Printf("[WARN] template '%s' does not match the installed version - moved to '%s' before updating", d.Name(), backupDirectory)
+		}
+
+		if err := writeTemplate(d.Name(), targetDirectory); err != nil {
+			log.Println("[ERROR] error copying template", err)
 			return err
 		}
+		versionFile.Templates[d.Name()] = version
+		updated = true
 	}
-	return nil
+	if !updated {
+		return nil
+	}
+	return versionFile.save()
+}
+
+// templateVersionFile records the version of each installed built-in template
+type templateVersionFile struct {
+	Templates map[string]string `json:"templates"`
+}
+
+func loadTemplateVersionFile() (*templateVersionFile, error) {
+	versionFile := &templateVersionFile{Templates: make(map[string]string)}
+
+	data, err := os.ReadFile(filepaths.TemplateVersionFilePath())
+	if os.IsNotExist(err) {
+		// templates installed before the version file was introduced will be checked against the built-in versions
+		return versionFile, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, versionFile); err != nil {
+		log.Println("[WARN] error reading template version file - all templates will be updated", err)
+		return &templateVersionFile{Templates: make(map[string]string)}, nil
+	}
+	if versionFile.Templates == nil {
+		versionFile.Templates = make(map[string]string)
+	}
+	return versionFile, nil
+}
+
+func (f *templateVersionFile) save() error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepaths.TemplateVersionFilePath(), data, 0644)
+}
+
+// the version of a template is a hash of the names and contents of the files in its directory
+func templateVersion(fsys fs.FS, dir string) (string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	// ReadDir returns entries sorted by filename, so the hash is stable
+	h := sha256.New()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		bytes, err := fs.ReadFile(fsys, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(entry.Name()))
+		h.Write(bytes)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// backupTemplate moves the installed template into the template backup directory, returning the backup location
+func backupTemplate(name string, installedDirectory string) (string, error) {
+	backupDirectory := filepath.Join(filepaths.EnsureTemplateBackupDir(), fmt.Sprintf("%s-%s", name, time.Now().Format("20060102150405")))
+	if err := os.Rename(installedDirectory, backupDirectory); err != nil {
+		return "", err
+	}
+	return backupDirectory, nil
 }
 
 func writeTemplate(path string, target string) error {
@@ -0,0 +1,93 @@
+package controldisplay
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/filepaths"
+)
+
+func TestEnsureTemplates(t *testing.T) {
+	defer func(dir string) { filepaths.SteampipeDir = dir }(filepaths.SteampipeDir)
+	filepaths.SteampipeDir = t.TempDir()
+
+	builtinTemplate, err := fs.ReadFile(builtinTemplateFS, "templates/html/output.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	installedTemplatePath := filepath.Join(filepaths.EnsureTemplateDir(), "html", "output.tmpl")
+	writeInstalledTemplate := func(content string) {
+		if err := os.MkdirAll(filepath.Dir(installedTemplatePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(installedTemplatePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readInstalledTemplate := func() string {
+		installed, _ := os.ReadFile(installedTemplatePath)
+		return string(installed)
+	}
+	backups := func() int {
+		entries, _ := os.ReadDir(filepaths.EnsureTemplateBackupDir())
+		return len(entries)
+	}
+
+	// simulate a template installed by a previous version, before the version file existed
+	writeInstalledTemplate("customised")
+
+	// the unversioned template should be backed up before being updated, and any missing templates installed
+	if err := EnsureTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	if readInstalledTemplate() != string(builtinTemplate) {
+		t.Errorf("expected the unversioned html template to be updated")
+	}
+	if backups() != 1 {
+		t.Errorf("expected the unversioned html template to be backed up")
+	} else {
+		entries, _ := os.ReadDir(filepaths.EnsureTemplateBackupDir())
+		backup, _ := os.ReadFile(filepath.Join(filepaths.EnsureTemplateBackupDir(), entries[0].Name(), "output.tmpl"))
+		if string(backup) != "customised" {
+			t.Errorf("expected the backup to contain the customised template, got '%s'", string(backup))
+		}
+	}
+	if _, err := os.Stat(filepath.Join(filepaths.EnsureTemplateDir(), "json", "output.tmpl")); err != nil {
+		t.Errorf("expected the missing json template to be installed: %v", err)
+	}
+
+	// now the installed template is up to date, so it should not be overwritten again
+	writeInstalledTemplate("customised")
+	if err := EnsureTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	if readInstalledTemplate() != "customised" {
+		t.Errorf("expected an up to date template not to be overwritten")
+	}
+
+	// simulate an unmodified template installed by a previous version, whose built-in template has since changed
+	writeInstalledTemplate("previous version")
+	previousVersion, err := templateVersion(os.DirFS(filepath.Dir(installedTemplatePath)), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	versionFile, err := loadTemplateVersionFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	versionFile.Templates["html"] = previousVersion
+	if err := versionFile.save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnsureTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	if readInstalledTemplate() != string(builtinTemplate) {
+		t.Errorf("expected the unmodified outdated template to be overwritten")
+	}
+	if backups() != 1 {
+		t.Errorf("expected the unmodified outdated template not to be backed up")
+	}
+}
@@ -168,3 +168,28 @@ var exportFormatTestCases map[string]Testcase = map[string]Testcase{
 		},
 	},
 }
+
+// resolving an export filename by extension, using the built-in templates
+var builtinExportFilenameTestCases = map[string]string{
+	"x.xml":        "nunit3.xml",
+	"x.nunit3.xml": "nunit3.xml",
+	"x.junit.xml":  "junit.xml",
+	"x.sarif":      "sarif.sarif",
+	"x.json":       "json.json",
+	"x.asff.json":  "asff.json",
+	"x.html":       "html.html",
+}
+
+func TestFindTemplateByFilenameBuiltin(t *testing.T) {
+	available := builtinExportTemplates(t)
+	for filename, expected := range builtinExportFilenameTestCases {
+		template, err := findTemplateByFilename(filename, available)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED with unexpected error: %v", filename, err)
+			continue
+		}
+		if template.FormatFullName != expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", filename, expected, template.FormatFullName)
+		}
+	}
+}
@@ -32,6 +32,7 @@ func (r ResultStatusRenderer) Render() string {
 }
 
 // pad out status toi length of longest status string = "ERROR" - 5 chars
+// (NOTE: "SUPPRESSED" is longer so is not padded)
 func (r ResultStatusRenderer) paddedStatusString() string {
 	return fmt.Sprintf("%-5s", strings.ToUpper(r.status))
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/control/controlexecute"
 )
 
@@ -61,6 +62,10 @@ func (r SummaryRenderer) Render() string {
 		alarmStatusRow,
 		errorStatusRow,
 	}
+	// only show suppressed results if there are any
+	if r.resultTree.Root.Summary.Status.Suppressed > 0 {
+		summaryLines = append(summaryLines, NewSummaryStatusRowRenderer(r.resultTree, availableWidth, constants.ControlSuppressed).Render())
+	}
 	// if there is a severity block, add it
 	if len(severityRows) > 0 {
 		summaryLines = append(summaryLines, "") // blank line
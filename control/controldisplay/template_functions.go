@@ -10,12 +10,13 @@ import (
 	"time"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/turbot/steampipe/control/controlexecute"
 )
 
 // templateFuncs merges desired functions from sprig with custom functions that we
 // define in steampipe
 func templateFuncs() template.FuncMap {
-	useFromSprigMap := []string{"upper", "toJson", "quote", "dict", "add", "now", "toPrettyJson"}
+	useFromSprigMap := []string{"upper", "lower", "toJson", "quote", "dict", "add", "now", "toPrettyJson"}
 
 	var funcs template.FuncMap = template.FuncMap{}
 	sprigMap := sprig.TxtFuncMap()
@@ -39,8 +40,10 @@ func templateFuncs() template.FuncMap {
 
 // custom steampipe functions - ones we couldn't find in sprig
 var formatterTemplateFuncMap template.FuncMap = template.FuncMap{
-	"durationInSeconds": durationInSeconds,
-	"toCsvCell":         toCsvCell,
+	"durationInSeconds":   durationInSeconds,
+	"toCsvCell":           toCsvCell,
+	"distinctControlRuns": distinctControlRuns,
+	"controlIndexes":      controlIndexes,
 }
 
 var (
@@ -62,3 +65,29 @@ func toCsvCell(v interface{}) string {
 
 // durationInSeconds returns the passed in duration as seconds
 func durationInSeconds(t time.Duration) float64 { return t.Seconds() }
+
+// distinctControlRuns returns the first run of each control - a control may be run more than once
+// if it is a child of more than one benchmark
+func distinctControlRuns(runs []*controlexecute.ControlRun) []*controlexecute.ControlRun {
+	var res []*controlexecute.ControlRun
+	controls := make(map[string]bool)
+	for _, run := range runs {
+		if controls[run.Control.Name()] {
+			continue
+		}
+		controls[run.Control.Name()] = true
+		res = append(res, run)
+	}
+	return res
+}
+
+// controlIndexes returns a map of the index of each control in the given runs, keyed by control name
+func controlIndexes(runs []*controlexecute.ControlRun) map[string]int {
+	res := make(map[string]int, len(runs))
+	for idx, run := range runs {
+		if _, ok := res[run.Control.Name()]; !ok {
+			res[run.Control.Name()] = idx
+		}
+	}
+	return res
+}
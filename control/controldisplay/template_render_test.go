@@ -0,0 +1,202 @@
+package controldisplay
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+	"github.com/turbot/steampipe/version"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the template render tests")
+
+// build a small execution tree - a benchmark with a failing control, a passing control and a skipped control
+func testRenderExecutionTree() *controlexecute.ExecutionTree {
+	startTime := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	tree := &controlexecute.ExecutionTree{
+		StartTime: startTime,
+		EndTime:   startTime.Add(5 * time.Second),
+	}
+	root := &controlexecute.ResultGroup{GroupId: controlexecute.RootResultGroupName}
+	benchmark := &controlexecute.ResultGroup{
+		GroupId:  "m1.benchmark.b1",
+		Title:    "Benchmark 1",
+		Parent:   root,
+		Duration: 5 * time.Second,
+	}
+	root.Groups = []*controlexecute.ResultGroup{benchmark}
+	tree.Root = root
+
+	newControlRun := func(shortName, title, severity string, summary controlexecute.StatusSummary, rows []*controlexecute.ResultRow) *controlexecute.ControlRun {
+		control := &modconfig.Control{
+			ShortName:       shortName,
+			FullName:        "m1.control." + shortName,
+			UnqualifiedName: "control." + shortName,
+			Title:           utils.ToStringPointer(title),
+			Description:     utils.ToStringPointer(title + " description"),
+			Severity:        utils.ToStringPointer(severity),
+			Tags:            map[string]string{"service": "test"},
+		}
+		run := &controlexecute.ControlRun{
+			Control:   control,
+			ControlId: control.UnqualifiedName,
+			Title:     title,
+			Severity:  severity,
+			Summary:   summary,
+			Rows:      rows,
+			Duration:  time.Second,
+			Group:     benchmark,
+			Tree:      tree,
+		}
+		for _, row := range rows {
+			row.Control = control
+			row.Run = run
+		}
+		return run
+	}
+
+	benchmark.ControlRuns = []*controlexecute.ControlRun{
+		newControlRun("c1", "Control 1", "high", controlexecute.StatusSummary{Alarm: 1, Ok: 1}, []*controlexecute.ResultRow{
+			{Resource: "arn:r1", Reason: "r1 is <insecure>", Status: constants.ControlAlarm, Dimensions: []controlexecute.Dimension{{Key: "region", Value: "us-east-1"}}},
+			{Resource: "arn:r2", Reason: "r2 is ok", Status: constants.ControlOk},
+		}),
+		newControlRun("c2", "Control 2", "low", controlexecute.StatusSummary{Ok: 1}, []*controlexecute.ResultRow{
+			{Resource: "arn:r3", Reason: "r3 is ok", Status: constants.ControlOk},
+		}),
+		newControlRun("c3", "Control 3", "medium", controlexecute.StatusSummary{Skip: 1}, []*controlexecute.ResultRow{
+			{Resource: "arn:r4", Reason: "r4 is skipped", Status: constants.ControlSkip},
+		}),
+	}
+	tree.ControlRuns = benchmark.ControlRuns
+	return tree
+}
+
+// render the test execution tree using the given built-in template and compare with the golden file
+func testRenderTemplate(t *testing.T, format string) {
+	exportTemplate, err := findTemplateByFilename(format, builtinExportTemplates(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatter, err := NewTemplateFormatter(*exportTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := formatter.Format(context.Background(), testRenderExecutionTree())
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the values which depend on the environment
+	workingDir, _ := os.Getwd()
+	rendered := strings.ReplaceAll(string(output), workingDir, "WORKING_DIR")
+	rendered = strings.ReplaceAll(rendered, version.SteampipeVersion.String(), "STEAMPIPE_VERSION")
+
+	goldenPath := filepath.Join("test_data", "templates", exportTemplate.FormatFullName+".golden")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, []byte(rendered), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered != string(expected) {
+		t.Errorf("%s output does not match %s:\n%s", format, goldenPath, rendered)
+	}
+}
+
+func builtinExportTemplates(t *testing.T) []*ExportTemplate {
+	entries, err := os.ReadDir("templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []*ExportTemplate
+	for _, entry := range entries {
+		res = append(res, NewExportTemplate(filepath.Join("templates", entry.Name())))
+	}
+	return res
+}
+
+func TestRenderJUnitTemplate(t *testing.T) {
+	testRenderTemplate(t, "output."+constants.CheckOutputFormatJUnit+".xml")
+}
+
+func TestRenderSarifTemplate(t *testing.T) {
+	testRenderTemplate(t, "output."+constants.CheckOutputFormatSarif)
+}
+
+func TestRenderSarifTemplateDuplicateControls(t *testing.T) {
+	exportTemplate, err := findTemplateByFilename("output."+constants.CheckOutputFormatSarif, builtinExportTemplates(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatter, err := NewTemplateFormatter(*exportTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// run the first control again, as would happen if it was a child of a second benchmark
+	tree := testRenderExecutionTree()
+	firstRun := tree.ControlRuns[0]
+	duplicateRun := &controlexecute.ControlRun{
+		Control:   firstRun.Control,
+		ControlId: firstRun.ControlId,
+		Title:     firstRun.Title,
+		Severity:  firstRun.Severity,
+		Summary:   firstRun.Summary,
+		Rows:      firstRun.Rows,
+		Tree:      tree,
+	}
+	tree.ControlRuns = append(tree.ControlRuns, duplicateRun)
+
+	reader, err := formatter.Format(context.Background(), tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sarif struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						Id string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleId    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.NewDecoder(reader).Decode(&sarif); err != nil {
+		t.Fatalf("sarif output is not valid json: %s", err.Error())
+	}
+
+	rules := sarif.Runs[0].Tool.Driver.Rules
+	if len(rules) != 3 {
+		t.Errorf("expected a rule for each of the 3 controls, got %d", len(rules))
+	}
+	results := sarif.Runs[0].Results
+	if len(results) != 6 {
+		t.Fatalf("expected 6 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.RuleIndex >= len(rules) || rules[result.RuleIndex].Id != result.RuleId {
+			t.Errorf("result of %s has rule index %d which does not refer to its rule", result.RuleId, result.RuleIndex)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+// the baseline status of a result row
+const (
+	// the row is failing and was not failing in the baseline
+	BaselineStatusNew = "new"
+	// the row is failing and was also failing in the baseline
+	BaselineStatusExisting = "existing"
+	// the row was failing in the baseline but is no longer failing
+	BaselineStatusResolved = "resolved"
+)
+
+// Baseline is the set of failing results from a previous check run, loaded from a JSON export
+type Baseline struct {
+	// map of control id to its failing rows, keyed by baselineKey
+	failures map[string]map[string]*ResultRow
+}
+
+// BaselineSummary is the count of new, existing and resolved failures compared to the baseline
+type BaselineSummary struct {
+	New      int `json:"new"`
+	Existing int `json:"existing"`
+	// NOTE: this includes baseline failures for resources which are no longer returned by the control
+	Resolved int `json:"resolved"`
+	// the baseline failures which have been resolved
+	ResolvedRows []*BaselineResolvedRow `json:"resolved_rows,omitempty"`
+}
+
+// BaselineResolvedRow is a failing row from the baseline which is no longer failing
+type BaselineResolvedRow struct {
+	ControlId  string      `json:"control_id"`
+	Resource   string      `json:"resource"`
+	Reason     string      `json:"reason"`
+	Dimensions []Dimension `json:"dimensions"`
+}
+
+func (r *BaselineResolvedRow) String() string {
+	dimensions := make([]string, len(r.Dimensions))
+	for i, dim := range r.Dimensions {
+		dimensions[i] = fmt.Sprintf("%s=%s", dim.Key, dim.Value)
+	}
+	if len(dimensions) == 0 {
+		return fmt.Sprintf("%s: %s", r.ControlId, r.Resource)
+	}
+	return fmt.Sprintf("%s: %s (%s)", r.ControlId, r.Resource, strings.Join(dimensions, ", "))
+}
+
+// the subset of the JSON export of a result group which is used to build a baseline
+type baselineResultGroup struct {
+	Groups   []*baselineResultGroup `json:"groups"`
+	Controls []*baselineControlRun  `json:"controls"`
+}
+
+type baselineControlRun struct {
+	ControlId string       `json:"control_id"`
+	Results   []*ResultRow `json:"results"`
+}
+
+// LoadBaseline loads the failing results from a JSON check export
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %v", err)
+	}
+	var root baselineResultGroup
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to read baseline '%s' - expected the JSON output of a check: %v", path, err)
+	}
+
+	baseline := &Baseline{failures: make(map[string]map[string]*ResultRow)}
+	baseline.addGroup(&root)
+	return baseline, nil
+}
+
+func (b *Baseline) addGroup(group *baselineResultGroup) {
+	for _, controlRun := range group.Controls {
+		for _, row := range controlRun.Results {
+			if isFailure(row.Status) {
+				if b.failures[controlRun.ControlId] == nil {
+					b.failures[controlRun.ControlId] = make(map[string]*ResultRow)
+				}
+				b.failures[controlRun.ControlId][baselineKey(row)] = row
+			}
+		}
+	}
+	for _, child := range group.Groups {
+		b.addGroup(child)
+	}
+}
+
+// annotate sets the baseline status of the rows of all control runs, returning a summary of the changes
+func (b *Baseline) annotate(controlRuns []*ControlRun) *BaselineSummary {
+	summary := &BaselineSummary{}
+
+	for _, controlRun := range controlRuns {
+		baselineFailures := b.failures[controlRun.ControlId]
+		// if the control failed to run we cannot tell whether its baseline failures have been resolved
+		if controlRun.GetError() != nil {
+			continue
+		}
+
+		// keep track of which baseline failures are still failing
+		stillFailing := make(map[string]bool)
+		for _, row := range controlRun.Rows {
+			key := baselineKey(row)
+			_, inBaseline := baselineFailures[key]
+
+			switch {
+			case isFailure(row.Status) && inBaseline:
+				row.BaselineStatus = BaselineStatusExisting
+				stillFailing[key] = true
+				summary.Existing++
+			case isFailure(row.Status):
+				row.BaselineStatus = BaselineStatusNew
+				summary.New++
+			case inBaseline:
+				row.BaselineStatus = BaselineStatusResolved
+			}
+		}
+		// every baseline failure which is no longer failing has been resolved
+		// (this includes those for resources which are no longer returned)
+		var resolvedKeys []string
+		for key := range baselineFailures {
+			if !stillFailing[key] {
+				resolvedKeys = append(resolvedKeys, key)
+			}
+		}
+		sort.Strings(resolvedKeys)
+		for _, key := range resolvedKeys {
+			row := baselineFailures[key]
+			summary.ResolvedRows = append(summary.ResolvedRows, &BaselineResolvedRow{
+				ControlId:  controlRun.ControlId,
+				Resource:   row.Resource,
+				Reason:     row.Reason,
+				Dimensions: row.Dimensions,
+			})
+		}
+		summary.Resolved += len(resolvedKeys)
+	}
+	return summary
+}
+
+// within a control, rows are identified by resource and dimensions
+// (the reason is not used as it may include values which change between runs)
+func baselineKey(row *ResultRow) string {
+	dimensions := make([]string, len(row.Dimensions))
+	for i, dim := range row.Dimensions {
+		dimensions[i] = fmt.Sprintf("%s=%s", dim.Key, dim.Value)
+	}
+	sort.Strings(dimensions)
+	return fmt.Sprintf("%s|%s", row.Resource, strings.Join(dimensions, ","))
+}
+
+func isFailure(status string) bool {
+	return status == constants.ControlAlarm || status == constants.ControlError
+}
@@ -0,0 +1,91 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+func TestBaselineAnnotate(t *testing.T) {
+	baseline := &Baseline{failures: map[string]map[string]*ResultRow{
+		"control.c1": {
+			baselineKey(&ResultRow{Resource: "r1"}): {Resource: "r1", Reason: "r1 failed"},
+			baselineKey(&ResultRow{Resource: "r2"}): {Resource: "r2", Reason: "r2 failed"},
+			baselineKey(&ResultRow{Resource: "r3"}): {Resource: "r3", Reason: "r3 failed"},
+		},
+	}}
+
+	rows := []*ResultRow{
+		// still failing
+		{Resource: "r1", Status: constants.ControlAlarm},
+		// fixed
+		{Resource: "r2", Status: constants.ControlOk},
+		// new failure
+		{Resource: "r4", Status: constants.ControlError},
+		// ok, not in baseline
+		{Resource: "r5", Status: constants.ControlOk},
+	}
+	controlRun := &ControlRun{ControlId: "control.c1", Rows: rows}
+
+	summary := baseline.annotate([]*ControlRun{controlRun})
+
+	expectedStatuses := []string{BaselineStatusExisting, BaselineStatusResolved, BaselineStatusNew, ""}
+	for i, row := range rows {
+		if row.BaselineStatus != expectedStatuses[i] {
+			t.Errorf("row %s: expected baseline status '%s', got '%s'", row.Resource, expectedStatuses[i], row.BaselineStatus)
+		}
+	}
+	// r2 was fixed and r3 is no longer returned
+	expectedSummary := &BaselineSummary{New: 1, Existing: 1, Resolved: 2, ResolvedRows: []*BaselineResolvedRow{
+		{ControlId: "control.c1", Resource: "r2", Reason: "r2 failed"},
+		{ControlId: "control.c1", Resource: "r3", Reason: "r3 failed"},
+	}}
+	if !reflect.DeepEqual(summary, expectedSummary) {
+		t.Errorf("expected summary %+v, got %+v", expectedSummary, summary)
+	}
+}
+
+// the JSON output of the root result group includes the baseline summary, and can itself be used as a baseline
+func TestBaselineJSONExport(t *testing.T) {
+	baselineSummary := &BaselineSummary{New: 1, Resolved: 1, ResolvedRows: []*BaselineResolvedRow{
+		{ControlId: "control.c1", Resource: "r2", Reason: "r2 failed", Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}},
+	}}
+	root := &ResultGroup{
+		GroupId: RootResultGroupName,
+		ControlRuns: []*ControlRun{{
+			ControlId: "control.c1",
+			Rows:      []*ResultRow{{Resource: "r1", Status: constants.ControlAlarm, BaselineStatus: BaselineStatusNew}},
+		}},
+		Baseline: baselineSummary,
+	}
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var exported struct {
+		Baseline *BaselineSummary `json:"baseline"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exported.Baseline, baselineSummary) {
+		t.Errorf("expected exported baseline %+v, got %+v", baselineSummary, exported.Baseline)
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := baseline.failures["control.c1"][baselineKey(&ResultRow{Resource: "r1"})]; !ok {
+		t.Errorf("expected the exported failure to be loaded as a baseline failure")
+	}
+}
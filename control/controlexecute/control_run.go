@@ -393,12 +393,14 @@ func (r *ControlRun) addResultRow(row *ResultRow) {
 		r.Summary.Info++
 	case constants.ControlError:
 		r.Summary.Error++
+	case constants.ControlSuppressed:
+		r.Summary.Suppressed++
 	}
 }
 
 // populate ordered list of rows
 func (r *ControlRun) createdOrderedResultRows() {
-	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip}
+	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip, constants.ControlSuppressed}
 	for _, status := range statusOrder {
 		r.Rows = append(r.Rows, r.rowMap[status]...)
 	}
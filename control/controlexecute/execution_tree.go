@@ -29,9 +29,14 @@ type ExecutionTree struct {
 	Progress    *controlhooks.ControlProgress `json:"progress"`
 	// map of dimension property name to property value to color map
 	DimensionColorGenerator *DimensionColorGenerator `json:"-"`
+	// optional baseline - if set, result rows are annotated with their status compared to the baseline
+	Baseline        *Baseline        `json:"-"`
+	BaselineSummary *BaselineSummary `json:"baseline,omitempty"`
 
 	workspace *workspace.Workspace
 	client    db_common.Client
+	// the unexpired suppressions - matching failing rows are given the status 'suppressed'
+	suppressions []*modconfig.Suppression
 	// an optional map of control names used to filter the controls which are run
 	controlNameFilterMap map[string]bool
 }
@@ -40,8 +45,9 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 	// TOTO [reports] FAIL IF any resources in the tree have runtime dependencies
 	// now populate the ExecutionTree
 	executionTree := &ExecutionTree{
-		workspace: workspace,
-		client:    client,
+		workspace:    workspace,
+		client:       client,
+		suppressions: workspace.GetActiveSuppressions(time.Now()),
 	}
 	// if a "--where" or "--tag" parameter was passed, build a map of control names used to filter the controls to run
 	// create a context with status hooks disabled
@@ -117,6 +123,16 @@ func (e *ExecutionTree) Execute(ctx context.Context) int {
 
 	failures := e.Root.Summary.Status.Alarm + e.Root.Summary.Status.Error
 
+	if e.Baseline != nil {
+		e.BaselineSummary = e.Baseline.annotate(e.ControlRuns)
+		// include the summary in the root result group, so it is included in exports
+		e.Root.Baseline = e.BaselineSummary
+		if viper.GetString(constants.ArgFailOn) == constants.FailOnNew {
+			// only count new failures (and controls which failed to run)
+			failures = e.BaselineSummary.New + e.controlRunErrorCount()
+		}
+	}
+
 	// now build map of dimension property name to property value to color map
 	e.DimensionColorGenerator, _ = NewDimensionColorGenerator(4, 27)
 	e.DimensionColorGenerator.populate(e)
@@ -124,6 +140,17 @@ func (e *ExecutionTree) Execute(ctx context.Context) int {
 	return failures
 }
 
+// the number of control runs which failed to run
+func (e *ExecutionTree) controlRunErrorCount() int {
+	count := 0
+	for _, r := range e.ControlRuns {
+		if r.GetError() != nil {
+			count++
+		}
+	}
+	return count
+}
+
 func (e *ExecutionTree) populateControlFilterMap(ctx context.Context) error {
 	// if both '--where' and '--tag' have been used, then it's an error
 	if viper.IsSet(constants.ArgWhere) && viper.IsSet(constants.ArgTag) {
@@ -33,6 +33,8 @@ type ResultGroup struct {
 	// child control runs
 	ControlRuns []*ControlRun            `json:"controls"`
 	Severity    map[string]StatusSummary `json:"-"`
+	// the comparison with the baseline, if one was provided (only set for the root group)
+	Baseline *BaselineSummary `json:"baseline,omitempty"`
 
 	// the control tree item associated with this group(i.e. a mod/benchmark)
 	GroupItem modconfig.ModTreeItem `json:"-"`
@@ -207,6 +209,7 @@ func (r *ResultGroup) updateSummary(summary StatusSummary) {
 	r.Summary.Status.Info += summary.Info
 	r.Summary.Status.Ok += summary.Ok
 	r.Summary.Status.Error += summary.Error
+	r.Summary.Status.Suppressed += summary.Suppressed
 
 	if r.Parent != nil {
 		r.Parent.updateSummary(summary)
@@ -226,6 +229,7 @@ func (r *ResultGroup) updateSeverityCounts(severity string, summary StatusSummar
 	val.Info += summary.Info
 	val.Ok += summary.Ok
 	val.Skip += summary.Skip
+	val.Suppressed += summary.Suppressed
 
 	r.Summary.Severity[severity] = val
 	if r.Parent != nil {
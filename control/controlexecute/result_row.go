@@ -19,10 +19,14 @@ type ResultRow struct {
 	Reason string `json:"reason" csv:"reason"`
 	// resource name
 	Resource string `json:"resource" csv:"resource"`
-	// status of the row (ok, info, alarm, error, skip)
+	// status of the row (ok, info, alarm, error, skip, suppressed)
 	Status string `json:"status" csv:"status"`
 	// dimensions for this row
 	Dimensions []Dimension `json:"dimensions"`
+	// status of the row compared to the baseline, if one was provided (new, existing or resolved)
+	BaselineStatus string `json:"baseline_status,omitempty"`
+	// if the row has been suppressed, details of the suppression
+	Suppression *ResultSuppression `json:"suppression,omitempty"`
 	// parent control run
 	Run *ControlRun `json:"-"`
 	// source control
@@ -82,9 +86,31 @@ func NewResultRow(run *ControlRun, row *queryresult.RowResult, colTypes []*sql.C
 			res.AddDimension(c, row.Data[i])
 		}
 	}
+	if run.Tree != nil {
+		res.applySuppressions(run.Tree.suppressions)
+	}
 	return res, nil
 }
 
+// if the row is failing and matches a suppression, set the status to suppressed
+func (r *ResultRow) applySuppressions(suppressions []*modconfig.Suppression) {
+	if r.Status != constants.ControlAlarm && r.Status != constants.ControlError {
+		return
+	}
+	for _, suppression := range suppressions {
+		if suppression.Matches(r.Control, r.Resource, r.GetDimensionValue) {
+			r.Suppression = &ResultSuppression{
+				Name:           suppression.Name,
+				Justification:  suppression.Justification,
+				Expires:        suppression.Expires,
+				OriginalStatus: r.Status,
+			}
+			r.Status = constants.ControlSuppressed
+			return
+		}
+	}
+}
+
 func IsValidControlStatus(status string) bool {
 	return helpers.StringSliceContains([]string{constants.ControlOk, constants.ControlAlarm, constants.ControlInfo, constants.ControlError, constants.ControlSkip}, status)
 }
@@ -0,0 +1,69 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type applySuppressionsTest struct {
+	status     string
+	resource   string
+	dimensions []Dimension
+	expected   string
+}
+
+var testSuppressions = []*modconfig.Suppression{
+	{Name: "s1", Control: "control.c1", Resource: "r1", Justification: "accepted"},
+	{Name: "s2", Control: "m1.control.c1", Resource: "r2", Dimensions: map[string]string{"region": "us-east-1"}, Justification: "accepted"},
+}
+
+var testCasesApplySuppressions = map[string]applySuppressionsTest{
+	"alarm suppressed": {
+		status:   constants.ControlAlarm,
+		resource: "r1",
+		expected: constants.ControlSuppressed,
+	},
+	"error suppressed": {
+		status:   constants.ControlError,
+		resource: "r1",
+		expected: constants.ControlSuppressed,
+	},
+	"ok not suppressed": {
+		status:   constants.ControlOk,
+		resource: "r1",
+		expected: constants.ControlOk,
+	},
+	"different resource": {
+		status:   constants.ControlAlarm,
+		resource: "r3",
+		expected: constants.ControlAlarm,
+	},
+	"dimension matches": {
+		status:     constants.ControlAlarm,
+		resource:   "r2",
+		dimensions: []Dimension{{Key: "region", Value: "us-east-1"}},
+		expected:   constants.ControlSuppressed,
+	},
+	"dimension does not match": {
+		status:     constants.ControlAlarm,
+		resource:   "r2",
+		dimensions: []Dimension{{Key: "region", Value: "us-west-2"}},
+		expected:   constants.ControlAlarm,
+	},
+}
+
+func TestApplySuppressions(t *testing.T) {
+	control := &modconfig.Control{FullName: "m1.control.c1", UnqualifiedName: "control.c1"}
+	for name, test := range testCasesApplySuppressions {
+		row := &ResultRow{Status: test.status, Resource: test.resource, Dimensions: test.dimensions, Control: control}
+		row.applySuppressions(testSuppressions)
+		if row.Status != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %s, got %s", name, test.expected, row.Status)
+		}
+		if suppressed := row.Suppression != nil; suppressed && row.Suppression.OriginalStatus != test.status {
+			t.Errorf("Test: '%s'' FAILED : expected original status %s, got %s", name, test.status, row.Suppression.OriginalStatus)
+		}
+	}
+}
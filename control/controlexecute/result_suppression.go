@@ -0,0 +1,12 @@
+package controlexecute
+
+import "time"
+
+// ResultSuppression contains the details of the suppression applied to a result row
+type ResultSuppression struct {
+	Name          string    `json:"name"`
+	Justification string    `json:"justification"`
+	Expires       time.Time `json:"expires"`
+	// the status of the row before it was suppressed (alarm or error)
+	OriginalStatus string `json:"original_status"`
+}
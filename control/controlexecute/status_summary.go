@@ -7,6 +7,8 @@ type StatusSummary struct {
 	Info  int `json:"info"`
 	Skip  int `json:"skip"`
 	Error int `json:"error"`
+	// failing results which have been suppressed
+	Suppressed int `json:"suppressed"`
 }
 
 func (s *StatusSummary) PassedCount() int {
@@ -18,5 +20,17 @@ func (s *StatusSummary) FailedCount() int {
 }
 
 func (s *StatusSummary) TotalCount() int {
-	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error
+	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error + s.Suppressed
+}
+
+// Counts returns the status counts as a map, keyed by status
+func (s *StatusSummary) Counts() map[string]int {
+	return map[string]int{
+		"alarm":      s.Alarm,
+		"ok":         s.Ok,
+		"info":       s.Info,
+		"skip":       s.Skip,
+		"error":      s.Error,
+		"suppressed": s.Suppressed,
+	}
 }
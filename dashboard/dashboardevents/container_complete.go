@@ -4,6 +4,8 @@ import "github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 
 type ContainerComplete struct {
 	Container dashboardinterfaces.DashboardNodeRun
+	// the id of the session which the execution belongs to
+	Session string
 }
 
 // IsDashboardEvent implements DashboardEvent interface
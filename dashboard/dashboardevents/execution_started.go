@@ -4,6 +4,8 @@ import "github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 
 type ExecutionStarted struct {
 	DashboardNode dashboardinterfaces.DashboardNodeRun `json:"dashboard"`
+	// the id of the session which the execution belongs to
+	Session string `json:"session"`
 }
 
 // IsDashboardEvent implements DashboardEvent interface
@@ -4,6 +4,8 @@ import "github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 
 type LeafNodeComplete struct {
 	Node dashboardinterfaces.DashboardNodeRun
+	// the id of the session which the execution belongs to
+	Session string
 }
 
 // IsDashboardEvent implements DashboardEvent interface
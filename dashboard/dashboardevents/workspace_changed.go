@@ -0,0 +1,8 @@
+package dashboardevents
+
+// WorkspaceChanged is raised when the workspace is reloaded and its resources have changed
+// - unlike DashboardChanged, this is also raised for changes to non-dashboard resources such as queries
+type WorkspaceChanged struct{}
+
+// IsDashboardEvent implements DashboardEvent interface
+func (*WorkspaceChanged) IsDashboardEvent() {}
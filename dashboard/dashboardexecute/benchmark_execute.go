@@ -0,0 +1,83 @@
+package dashboardexecute
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/workspace"
+)
+
+// BenchmarkExecution is the execution of a benchmark which is run directly, rather than as part of a dashboard
+type BenchmarkExecution struct {
+	Benchmark     string                                 `json:"benchmark"`
+	Status        dashboardinterfaces.DashboardRunStatus `json:"status"`
+	StartTime     time.Time                              `json:"start_time"`
+	EndTime       *time.Time                             `json:"end_time,omitempty"`
+	Error         string                                 `json:"error,omitempty"`
+	ExecutionTree *controlexecute.ExecutionTree          `json:"execution_tree,omitempty"`
+}
+
+// map of benchmark executions, keyed by benchmark name
+var benchmarkExecutions = make(map[string]*BenchmarkExecution)
+var benchmarkExecutionsLock sync.Mutex
+
+// ExecuteBenchmark starts an asynchronous execution of the given benchmark
+// the execution may be retrieved using GetBenchmarkExecution
+func ExecuteBenchmark(ctx context.Context, benchmarkName string, workspace *workspace.Workspace, client db_common.Client) error {
+	benchmarkExecutionsLock.Lock()
+	defer benchmarkExecutionsLock.Unlock()
+
+	if existing, ok := benchmarkExecutions[benchmarkName]; ok && existing.Status == dashboardinterfaces.DashboardRunReady {
+		return fmt.Errorf("benchmark %s is already running", benchmarkName)
+	}
+	executionTree, err := controlexecute.NewExecutionTree(ctx, workspace, client, benchmarkName)
+	if err != nil {
+		return err
+	}
+
+	execution := &BenchmarkExecution{
+		Benchmark:     benchmarkName,
+		Status:        dashboardinterfaces.DashboardRunReady,
+		StartTime:     time.Now(),
+		ExecutionTree: executionTree,
+	}
+	benchmarkExecutions[benchmarkName] = execution
+
+	go func() {
+		executionTree.Execute(ctx)
+
+		benchmarkExecutionsLock.Lock()
+		defer benchmarkExecutionsLock.Unlock()
+		endTime := time.Now()
+		execution.EndTime = &endTime
+		execution.Status = dashboardinterfaces.DashboardRunComplete
+		if ctx.Err() != nil {
+			execution.Status = dashboardinterfaces.DashboardRunError
+			execution.Error = ctx.Err().Error()
+		}
+	}()
+	return nil
+}
+
+// GetBenchmarkExecution returns a copy of the current (or most recent) execution of the given benchmark
+// the execution tree is only included once the execution has finished
+// - while it is running, the tree is being updated by the execution goroutines so cannot safely be serialised
+func GetBenchmarkExecution(benchmarkName string) (*BenchmarkExecution, bool) {
+	benchmarkExecutionsLock.Lock()
+	defer benchmarkExecutionsLock.Unlock()
+
+	execution, ok := benchmarkExecutions[benchmarkName]
+	if !ok {
+		return nil, false
+	}
+	res := *execution
+	if res.Status == dashboardinterfaces.DashboardRunReady {
+		res.ExecutionTree = nil
+	}
+	return &res, true
+}
@@ -0,0 +1,43 @@
+package dashboardexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+)
+
+func TestGetBenchmarkExecution(t *testing.T) {
+	testCases := map[string]struct {
+		status       dashboardinterfaces.DashboardRunStatus
+		expectedTree bool
+	}{
+		"running":  {status: dashboardinterfaces.DashboardRunReady, expectedTree: false},
+		"complete": {status: dashboardinterfaces.DashboardRunComplete, expectedTree: true},
+		"error":    {status: dashboardinterfaces.DashboardRunError, expectedTree: true},
+	}
+
+	for name, test := range testCases {
+		benchmarkName := "m1.benchmark." + name
+		benchmarkExecutionsLock.Lock()
+		benchmarkExecutions[benchmarkName] = &BenchmarkExecution{
+			Benchmark:     benchmarkName,
+			Status:        test.status,
+			ExecutionTree: &controlexecute.ExecutionTree{},
+		}
+		benchmarkExecutionsLock.Unlock()
+
+		execution, ok := GetBenchmarkExecution(benchmarkName)
+		if !ok {
+			t.Errorf("Test: '%s'' FAILED : execution not found", name)
+			continue
+		}
+		if hasTree := execution.ExecutionTree != nil; hasTree != test.expectedTree {
+			t.Errorf("Test: '%s'' FAILED : expected execution tree: %v, got: %v", name, test.expectedTree, hasTree)
+		}
+		// the stored execution must not be modified
+		if benchmarkExecutions[benchmarkName].ExecutionTree == nil {
+			t.Errorf("Test: '%s'' FAILED : stored execution tree was cleared", name)
+		}
+	}
+}
@@ -17,7 +17,8 @@ type CheckRun struct {
 	Name                 string                        `json:"name"`
 	Title                string                        `json:"title,omitempty"`
 	Width                int                           `json:"width,omitempty"`
-	Error                error                         `json:"error,omitempty"`
+	Error                error                         `json:"-"`
+	ErrorString          string                        `json:"error,omitempty"`
 	NodeType             string                        `json:"node_type"`
 	ControlExecutionTree *controlexecute.ExecutionTree `json:"execution_tree"`
 	DashboardName        string                        `json:"dashboard"`
@@ -101,9 +102,10 @@ func (r *CheckRun) GetRunStatus() dashboardinterfaces.DashboardRunStatus {
 // SetError implements DashboardNodeRun
 func (r *CheckRun) SetError(err error) {
 	r.Error = err
+	r.ErrorString = err.Error()
 	r.runStatus = dashboardinterfaces.DashboardRunError
 	// raise counter error event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeError{Node: r})
+	r.executionTree.publishEvent(&dashboardevents.LeafNodeError{Node: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 
@@ -113,7 +115,7 @@ func (r *CheckRun) SetError(err error) {
 func (r *CheckRun) SetComplete() {
 	r.runStatus = dashboardinterfaces.DashboardRunComplete
 	// raise counter complete event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeComplete{Node: r})
+	r.executionTree.publishEvent(&dashboardevents.LeafNodeComplete{Node: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 }
@@ -19,7 +19,8 @@ type DashboardContainerRun struct {
 	Height        int                                    `json:"height,omitempty"`
 	Source        string                                 `json:"source,omitempty"`
 	SQL           string                                 `json:"sql,omitempty"`
-	Error         error                                  `json:"error,omitempty"`
+	Error         error                                  `json:"-"`
+	ErrorString   string                                 `json:"error,omitempty"`
 	Children      []dashboardinterfaces.DashboardNodeRun `json:"children,omitempty"`
 	NodeType      string                                 `json:"node_type"`
 	Status        dashboardinterfaces.DashboardRunStatus `json:"status"`
@@ -168,9 +169,10 @@ func (r *DashboardContainerRun) GetRunStatus() dashboardinterfaces.DashboardRunS
 // tell parent we are done
 func (r *DashboardContainerRun) SetError(err error) {
 	r.Error = err
+	r.ErrorString = err.Error()
 	r.Status = dashboardinterfaces.DashboardRunError
 	// raise container error event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.ContainerError{Container: r})
+	r.executionTree.publishEvent(&dashboardevents.ContainerError{Container: r, Session: r.executionTree.sessionId})
 	r.parent.ChildCompleteChan() <- r
 
 }
@@ -179,7 +181,7 @@ func (r *DashboardContainerRun) SetError(err error) {
 func (r *DashboardContainerRun) SetComplete() {
 	r.Status = dashboardinterfaces.DashboardRunComplete
 	// raise container complete event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.ContainerComplete{Container: r})
+	r.executionTree.publishEvent(&dashboardevents.ContainerComplete{Container: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 }
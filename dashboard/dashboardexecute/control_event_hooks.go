@@ -23,8 +23,8 @@ func (c *ControlEventHooks) OnStart(ctx context.Context, _ *controlhooks.Control
 }
 
 func (c *ControlEventHooks) OnControlEvent(ctx context.Context, _ *controlhooks.ControlProgress) {
-	event := &dashboardevents.LeafNodeProgress{Node: c.CheckRun}
-	c.CheckRun.executionTree.workspace.PublishDashboardEvent(event)
+	event := &dashboardevents.LeafNodeProgress{Node: c.CheckRun, Session: c.CheckRun.executionTree.sessionId}
+	c.CheckRun.executionTree.publishEvent(event)
 }
 
 func (c *ControlEventHooks) OnDone(ctx context.Context, _ *controlhooks.ControlProgress) {
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 
+	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/db/db_common"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
@@ -23,12 +25,23 @@ type DashboardExecutionTree struct {
 	workspace     *workspace.Workspace
 	runComplete   chan dashboardinterfaces.DashboardNodeRun
 
+	// the id of the session which this execution belongs to
+	sessionId string
+	// the execution context - once this is cancelled, the execution no longer publishes events
+	ctx context.Context
+	// if set, the leaf runs bypass the leaf data cache
+	refresh bool
+
 	inputLock              sync.Mutex
 	inputDataSubscriptions map[string][]chan bool
+	// input values provided by the client, keyed by input name
+	inputValues map[string]interface{}
+	// the number of leaf re-executions in progress (caused by input changes)
+	reexecutions int32
 }
 
 // NewReportExecutionTree creates a result group from a ModTreeItem
-func NewReportExecutionTree(reportName string, client db_common.Client, workspace *workspace.Workspace) (*DashboardExecutionTree, error) {
+func NewReportExecutionTree(ctx context.Context, reportName string, sessionId string, client db_common.Client, workspace *workspace.Workspace) (*DashboardExecutionTree, error) {
 	// now populate the DashboardExecutionTree
 	reportExecutionTree := &DashboardExecutionTree{
 		client:                 client,
@@ -36,7 +49,10 @@ func NewReportExecutionTree(reportName string, client db_common.Client, workspac
 		workspace:              workspace,
 		runComplete:            make(chan dashboardinterfaces.DashboardNodeRun, 1),
 		inputDataSubscriptions: make(map[string][]chan bool),
+		inputValues:            make(map[string]interface{}),
 		dashboardName:          reportName,
+		sessionId:              sessionId,
+		ctx:                    ctx,
 	}
 
 	// create the root run node (either a report run or a counter run)
@@ -83,6 +99,20 @@ func (e *DashboardExecutionTree) runStatus() dashboardinterfaces.DashboardRunSta
 	return e.Root.GetRunStatus()
 }
 
+// executionComplete returns whether the execution is complete and no leaf runs are being re-executed
+// - only then may the tree be safely serialised
+func (e *DashboardExecutionTree) executionComplete() bool {
+	return e.Root.RunComplete() && atomic.LoadInt32(&e.reexecutions) == 0
+}
+
+func (e *DashboardExecutionTree) reexecutionStarted() {
+	atomic.AddInt32(&e.reexecutions, 1)
+}
+
+func (e *DashboardExecutionTree) reexecutionComplete() {
+	atomic.AddInt32(&e.reexecutions, -1)
+}
+
 // GetName implements DashboardNodeParent
 // use mod chort name - this will be the root name for all child runs
 func (e *DashboardExecutionTree) GetName() string {
@@ -98,7 +128,7 @@ func (e *DashboardExecutionTree) waitForRuntimeDependency(ctx context.Context, d
 	depChan := make(chan (bool), 1)
 	// TOTO [reports] for now verify we only bind inputs to args (somewhere)
 
-	e.subscribeToInput(dependency.PropertyPath.Name, depChan)
+	e.subscribeToInput(dependency.PropertyPath.ToResourceName(), depChan)
 
 	select {
 	case <-ctx.Done():
@@ -112,5 +142,62 @@ func (e *DashboardExecutionTree) subscribeToInput(inputName string, depChan chan
 	e.inputLock.Lock()
 	defer e.inputLock.Unlock()
 
+	// if the value was set since the dependency was checked, notify immediately
+	if _, ok := e.inputValues[inputName]; ok {
+		depChan <- true
+		return
+	}
 	e.inputDataSubscriptions[inputName] = append(e.inputDataSubscriptions[inputName], depChan)
 }
+
+// publishEvent publishes a dashboard event, unless the execution has been cancelled
+// (a cancelled execution has been replaced or its session has ended, so its events are stale)
+func (e *DashboardExecutionTree) publishEvent(event dashboardevents.DashboardEvent) {
+	if e.ctx.Err() != nil {
+		return
+	}
+	e.workspace.PublishDashboardEvent(event)
+}
+
+// SetInputs sets the client input values and notifies any runs waiting for them
+func (e *DashboardExecutionTree) SetInputs(inputValues map[string]interface{}) {
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+
+	for inputName, value := range inputValues {
+		e.inputValues[inputName] = value
+		// notify subscribers that the value is available
+		for _, depChan := range e.inputDataSubscriptions[inputName] {
+			depChan <- true
+		}
+		delete(e.inputDataSubscriptions, inputName)
+	}
+}
+
+// GetInputValue returns the client value of the given input (if set)
+func (e *DashboardExecutionTree) GetInputValue(inputName string) (interface{}, bool) {
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+
+	value, ok := e.inputValues[inputName]
+	return value, ok
+}
+
+// getInputDependentRuns returns the leaf runs which have already run and whose args depend on the given input
+// (runs which are still waiting for the input will be notified by SetInputs)
+func (e *DashboardExecutionTree) getInputDependentRuns(inputName string) []*LeafRun {
+	var res []*LeafRun
+	for _, run := range e.runs {
+		leafRun, ok := run.(*LeafRun)
+		if !ok || !leafRun.RunComplete() {
+			continue
+		}
+		for _, dependency := range leafRun.DashboardNode.GetRuntimeDependencies() {
+			if dependency.IsArgDependency() && dependency.PropertyPath.ToResourceName() == inputName {
+				res = append(res, leafRun)
+				break
+			}
+		}
+	}
+	return res
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	typehelpers "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/utils"
@@ -22,7 +23,8 @@ type DashboardRun struct {
 	Height        int                                    `json:"height,omitempty"`
 	Source        string                                 `json:"source,omitempty"`
 	SQL           string                                 `json:"sql,omitempty"`
-	Error         error                                  `json:"error,omitempty"`
+	Error         error                                  `json:"-"`
+	ErrorString   string                                 `json:"error,omitempty"`
 	Children      []dashboardinterfaces.DashboardNodeRun `json:"children,omitempty"`
 	NodeType      string                                 `json:"node_type"`
 	Status        dashboardinterfaces.DashboardRunStatus `json:"status"`
@@ -169,9 +171,10 @@ func (r *DashboardRun) GetRunStatus() dashboardinterfaces.DashboardRunStatus {
 // tell parent we are done
 func (r *DashboardRun) SetError(err error) {
 	r.Error = err
+	r.ErrorString = err.Error()
 	r.Status = dashboardinterfaces.DashboardRunError
 	// raise container error event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.ContainerError{Container: r})
+	r.executionTree.publishEvent(&dashboardevents.ContainerError{Container: r, Session: r.executionTree.sessionId})
 	r.parent.ChildCompleteChan() <- r
 
 }
@@ -180,7 +183,7 @@ func (r *DashboardRun) SetError(err error) {
 func (r *DashboardRun) SetComplete() {
 	r.Status = dashboardinterfaces.DashboardRunComplete
 	// raise container complete event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.ContainerComplete{Container: r})
+	r.executionTree.publishEvent(&dashboardevents.ContainerComplete{Container: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 }
@@ -214,9 +217,15 @@ func (r *DashboardRun) GetRuntimeDependency(dependency *modconfig.RuntimeDepende
 	}
 
 	// find the input corresponding to this dependency
-	input, ok := r.dashboardNode.GetInput(dependency.PropertyPath.Name)
+	inputName := dependency.PropertyPath.ToResourceName()
+	input, ok := r.dashboardNode.GetInput(inputName)
 	if !ok {
-		return nil, fmt.Errorf("dashboard %s does not contain input %s", r.dashboardNode.Name(), dependency.PropertyPath.ItemType)
+		return nil, fmt.Errorf("dashboard %s does not contain input %s", r.dashboardNode.Name(), inputName)
+	}
+	// a value provided by the client takes precedence over the value defined in the input
+	if value, ok := r.executionTree.GetInputValue(inputName); ok {
+		valueString := typehelpers.ToString(value)
+		return &valueString, nil
 	}
 	return input.Value, nil
 }
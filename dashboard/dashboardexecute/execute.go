@@ -3,6 +3,9 @@ package dashboardexecute
 import (
 	"context"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
@@ -10,28 +13,54 @@ import (
 	"github.com/turbot/steampipe/workspace"
 )
 
-// map of execution trees, keyed by dashboard name
+// map of execution trees, keyed by session id
+// (completed trees are retained so leaf runs may be re-executed when an input changes)
 var executions = make(map[string]*DashboardExecutionTree)
+var executionsLock sync.Mutex
 
-func ExecuteDashboardNode(ctx context.Context, dashboardName string, workspace *workspace.Workspace, client db_common.Client) error {
-	// TODO [reports] if this report is already running - cancel ??? fail???
-	if _, running := executions[dashboardName]; running {
+// ExecuteDashboardNode executes the given dashboard for the given session,
+// replacing any previous execution for that session
+// - the caller is responsible for cancelling the context of any previous execution,
+// which cancels its in-flight queries and stops it publishing events
+func ExecuteDashboardNode(ctx context.Context, sessionId, dashboardName string, inputs map[string]interface{}, workspace *workspace.Workspace, client db_common.Client) error {
+	return executeDashboardNode(ctx, sessionId, dashboardName, inputs, false, false, workspace, client)
+}
+
+// RefreshDashboardNode executes the given dashboard for the given session in the same way as ExecuteDashboardNode,
+// but bypasses the leaf data cache - all leaf queries are executed and the cache is updated with the results
+func RefreshDashboardNode(ctx context.Context, sessionId, dashboardName string, inputs map[string]interface{}, workspace *workspace.Workspace, client db_common.Client) error {
+	return executeDashboardNode(ctx, sessionId, dashboardName, inputs, true, false, workspace, client)
+}
+
+// StartDashboardNode executes the given dashboard for the given session in the same way as ExecuteDashboardNode
+// (or RefreshDashboardNode if refresh is set), unless the previous execution for that session is still running,
+// in which case an error is returned
+func StartDashboardNode(ctx context.Context, sessionId, dashboardName string, inputs map[string]interface{}, refresh bool, workspace *workspace.Workspace, client db_common.Client) error {
+	return executeDashboardNode(ctx, sessionId, dashboardName, inputs, refresh, true, workspace, client)
+}
+
+func executeDashboardNode(ctx context.Context, sessionId, dashboardName string, inputs map[string]interface{}, refresh, failIfRunning bool, workspace *workspace.Workspace, client db_common.Client) error {
+	executionsLock.Lock()
+	defer executionsLock.Unlock()
+
+	if existing, ok := executions[sessionId]; ok && failIfRunning && !existing.executionComplete() {
 		return fmt.Errorf("dashboard %s is already running", dashboardName)
 	}
-
-	executionTree, err := NewReportExecutionTree(dashboardName, client, workspace)
+	executionTree, err := NewReportExecutionTree(ctx, dashboardName, sessionId, client, workspace)
 	if err != nil {
 		return err
 	}
+	executionTree.refresh = refresh
+	// set any input values provided by the client
+	executionTree.SetInputs(inputs)
 
-	executions[dashboardName] = executionTree
+	executions[sessionId] = executionTree
 	go func() {
-		workspace.PublishDashboardEvent(&dashboardevents.ExecutionStarted{DashboardNode: executionTree.Root})
+		executionTree.publishEvent(&dashboardevents.ExecutionStarted{DashboardNode: executionTree.Root, Session: sessionId})
 		defer func() {
-			// remove tree from map of executions
-			delete(executions, dashboardName)
-			// send completed event
-			workspace.PublishDashboardEvent(&dashboardevents.ExecutionComplete{Dashboard: executionTree.Root})
+			// send dashboard complete and execution completed events
+			executionTree.publishEvent(&dashboardevents.DashboardComplete{Dashboard: executionTree.Root, Session: sessionId})
+			executionTree.publishEvent(&dashboardevents.ExecutionComplete{Dashboard: executionTree.Root, Session: sessionId})
 		}()
 
 		if err := executionTree.Execute(ctx); err != nil {
@@ -44,3 +73,68 @@ func ExecuteDashboardNode(ctx context.Context, dashboardName string, workspace *
 
 	return nil
 }
+
+// ClearExecution removes the execution tree of the given session
+// this is called when a session ends - the caller is responsible for cancelling the execution context
+func ClearExecution(sessionId string) {
+	executionsLock.Lock()
+	defer executionsLock.Unlock()
+
+	delete(executions, sessionId)
+}
+
+// OnInputChanged sets the input values on the execution tree of the given session
+// and re-executes any leaf runs whose args depend on the changed input
+func OnInputChanged(ctx context.Context, sessionId string, changedInput string, inputs map[string]interface{}) error {
+	executionsLock.Lock()
+	executionTree, ok := executions[sessionId]
+	executionsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("no dashboard is being executed for session %s", sessionId)
+	}
+
+	// determine the dependent runs before setting the inputs
+	// - any runs which are waiting for the input will be executed as a result of setting it
+	dependentRuns := executionTree.getInputDependentRuns(changedInput)
+	executionTree.SetInputs(inputs)
+
+	log.Printf("[TRACE] input %s changed - re-executing %d leaf runs of %s", changedInput, len(dependentRuns), executionTree.dashboardName)
+	for _, leafRun := range dependentRuns {
+		leafRun.startReexecute(ctx)
+	}
+	return nil
+}
+
+// GetDashboardSnapshot returns a snapshot of the most recent execution of the given session
+// a snapshot is only returned once the execution is complete
+// - while it is running, the tree is being updated by the execution goroutines so cannot safely be serialised
+func GetDashboardSnapshot(sessionId string) (*DashboardSnapshot, bool) {
+	executionsLock.Lock()
+	defer executionsLock.Unlock()
+
+	executionTree, ok := executions[sessionId]
+	if !ok || !executionTree.executionComplete() {
+		return nil, false
+	}
+	return &DashboardSnapshot{
+		SchemaVersion: snapshotSchemaVersion,
+		Dashboard:     executionTree.dashboardName,
+		EndTime:       time.Now(),
+		Status:        executionTree.Root.GetRunStatus(),
+		Root:          executionTree.Root,
+	}, true
+}
+
+// GetDashboardExecution returns the root run of the current (or most recent) execution of the given session,
+// and whether the execution is complete
+// the root run is being updated while the execution is running, so may only be serialised once it is complete
+func GetDashboardExecution(sessionId string) (root dashboardinterfaces.DashboardNodeRun, complete bool, ok bool) {
+	executionsLock.Lock()
+	defer executionsLock.Unlock()
+
+	executionTree, ok := executions[sessionId]
+	if !ok {
+		return nil, false, false
+	}
+	return executionTree.Root, executionTree.executionComplete(), true
+}
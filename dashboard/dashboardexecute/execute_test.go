@@ -0,0 +1,28 @@
+package dashboardexecute
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStartDashboardNodeAlreadyRunning(t *testing.T) {
+	ctx := context.Background()
+	sessionId := "TestStartDashboardNodeAlreadyRunning"
+	executionTree, _ := newTestExecutionTree(t, ctx, false)
+
+	executionsLock.Lock()
+	executions[sessionId] = executionTree
+	executionsLock.Unlock()
+	defer ClearExecution(sessionId)
+
+	// the execution tree has not completed, so the dashboard must not be started again
+	if err := StartDashboardNode(ctx, sessionId, executionTree.dashboardName, nil, false, nil, nil); err == nil {
+		t.Errorf("TestStartDashboardNodeAlreadyRunning FAILED : expected an error starting a running dashboard")
+	}
+
+	executionsLock.Lock()
+	defer executionsLock.Unlock()
+	if executions[sessionId] != executionTree {
+		t.Errorf("TestStartDashboardNodeAlreadyRunning FAILED : expected the running execution not to be replaced")
+	}
+}
@@ -0,0 +1,83 @@
+package dashboardexecute
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+)
+
+// the leaf data cache, shared by all executions
+// this means that when a number of clients view the same dashboard, the leaf queries are only executed once
+var leafCache = newLeafDataCache()
+
+type leafCacheItem struct {
+	data    *LeafData
+	expires time.Time
+}
+
+// leafDataCache is a cache of leaf query results, keyed by the resolved sql of the leaf
+// (the resolved sql includes any arg values, so leaves with different args have different keys)
+type leafDataCache struct {
+	items map[string]leafCacheItem
+	lock  sync.Mutex
+	// used to determine expiry - may be overridden by tests
+	now func() time.Time
+}
+
+func newLeafDataCache() *leafDataCache {
+	return &leafDataCache{
+		items: make(map[string]leafCacheItem),
+		now:   time.Now,
+	}
+}
+
+// get returns the cached data for the given sql, if it exists and has not expired
+func (c *leafDataCache) get(sql string) (*LeafData, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item, ok := c.items[sql]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(item.expires) {
+		delete(c.items, sql)
+		return nil, false
+	}
+	return item.data, true
+}
+
+// set adds the data to the cache, removing any expired items
+func (c *leafDataCache) set(sql string, data *LeafData, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for key, item := range c.items {
+		if !now.Before(item.expires) {
+			delete(c.items, key)
+		}
+	}
+	c.items[sql] = leafCacheItem{data: data, expires: now.Add(ttl)}
+}
+
+// clear removes all items from the cache
+func (c *leafDataCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.items = make(map[string]leafCacheItem)
+}
+
+// ClearLeafCache removes all cached leaf data
+// this is called when the workspace is reloaded, as the definitions of the prepared statements used by leaf queries may have changed
+func ClearLeafCache() {
+	leafCache.clear()
+}
+
+// leafCacheTTL returns the configured cache TTL - if this is zero, caching is disabled
+func leafCacheTTL() time.Duration {
+	return time.Duration(viper.GetInt(constants.ArgDashboardServerCacheTTL)) * time.Second
+}
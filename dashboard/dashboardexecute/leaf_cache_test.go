@@ -0,0 +1,65 @@
+package dashboardexecute
+
+import (
+	"testing"
+	"time"
+)
+
+type leafCacheTest struct {
+	sql      string
+	elapsed  time.Duration
+	expected bool
+}
+
+var testCasesLeafCache = map[string]leafCacheTest{
+	"cached sql": {
+		sql:      "select 1",
+		elapsed:  10 * time.Second,
+		expected: true,
+	},
+	"expired": {
+		sql:      "select 1",
+		elapsed:  time.Minute,
+		expected: false,
+	},
+	"different sql": {
+		sql:      "select 2",
+		elapsed:  0,
+		expected: false,
+	},
+	"different args": {
+		sql:      "execute query_q1('a')",
+		elapsed:  0,
+		expected: false,
+	},
+}
+
+func TestLeafDataCache(t *testing.T) {
+	start := time.Now()
+	for name, test := range testCasesLeafCache {
+		cache := newLeafDataCache()
+		cache.now = func() time.Time { return start }
+		data := &LeafData{}
+		cache.set("select 1", data, time.Minute)
+		cache.set("execute query_q1('b')", data, time.Minute)
+
+		cache.now = func() time.Time { return start.Add(test.elapsed) }
+		res, ok := cache.get(test.sql)
+		if ok != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected cache hit %v, got %v", name, test.expected, ok)
+			continue
+		}
+		if ok && res != data {
+			t.Errorf("Test: '%s'' FAILED : cached data does not match", name)
+		}
+	}
+}
+
+func TestLeafDataCacheClear(t *testing.T) {
+	cache := newLeafDataCache()
+	cache.set("execute query_q1('a')", &LeafData{}, time.Minute)
+	cache.clear()
+	if _, ok := cache.get("execute query_q1('a')"); ok {
+		t.Errorf("TestLeafDataCacheClear FAILED : expected no cached data after clear")
+	}
+}
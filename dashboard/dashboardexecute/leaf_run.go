@@ -2,6 +2,10 @@ package dashboardexecute
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
 
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
@@ -16,7 +20,8 @@ type LeafRun struct {
 	Width         int                         `json:"width,omitempty"`
 	SQL           string                      `json:"sql,omitempty"`
 	Data          *LeafData                   `json:"data,omitempty"`
-	Error         error                       `json:"error,omitempty"`
+	Error         error                       `json:"-"`
+	ErrorString   string                      `json:"error,omitempty"`
 	DashboardNode modconfig.DashboardLeafNode `json:"properties"`
 	NodeType      string                      `json:"node_type"`
 	DashboardName string                      `json:"dashboard"`
@@ -24,6 +29,12 @@ type LeafRun struct {
 	parent        dashboardinterfaces.DashboardNodeParent
 	runStatus     dashboardinterfaces.DashboardRunStatus
 	executionTree *DashboardExecutionTree
+
+	// re-executions of the leaf are serialised - starting a re-execution cancels any previous re-execution
+	reexecuteLock   sync.Mutex
+	cancelReexecute context.CancelFunc
+	// held while a re-execution updates the leaf
+	executeLock sync.Mutex
 }
 
 func NewLeafRun(resource modconfig.DashboardLeafNode, parent dashboardinterfaces.DashboardNodeParent, executionTree *DashboardExecutionTree) (*LeafRun, error) {
@@ -53,7 +64,8 @@ func NewLeafRun(resource modconfig.DashboardLeafNode, parent dashboardinterfaces
 	// if we have sql, set status to ready
 	if queryProvider, ok := resource.(modconfig.QueryProvider); ok {
 		sql, err := executionTree.workspace.ResolveQuery(queryProvider, nil)
-		if err != nil {
+		// if any args are populated by runtime dependencies, the sql is resolved again once they are available
+		if err != nil && !hasArgRuntimeDependencies(resource) {
 			return nil, err
 		}
 		r.SQL = sql
@@ -68,7 +80,9 @@ func NewLeafRun(resource modconfig.DashboardLeafNode, parent dashboardinterfaces
 // Execute implements DashboardRunNode
 func (r *LeafRun) Execute(ctx context.Context) error {
 	// if there are any unresolved runtime dependencies, wait for them
+	// (if the execution is cancelled while waiting, set the error status so the parent is notified of completion)
 	if err := r.waitForRuntimeDependencies(ctx); err != nil {
+		r.SetError(err)
 		return err
 	}
 
@@ -77,20 +91,90 @@ func (r *LeafRun) Execute(ctx context.Context) error {
 		return nil
 	}
 
-	var err error
-	queryResult, err := r.executionTree.client.ExecuteSync(ctx, r.SQL)
-	if err != nil {
+	if err := r.executeQuery(ctx); err != nil {
 		// set the error status on the counter - this will raise counter error event
 		r.SetError(err)
 		return err
 
 	}
-	r.Data = NewLeafData(queryResult)
 	// set complete status on counter - this will raise counter complete event
 	r.SetComplete()
 	return nil
 }
 
+// startReexecute asynchronously re-executes the leaf, cancelling any re-execution which is already running
+// the execution tree is not complete until the re-execution is done
+func (r *LeafRun) startReexecute(ctx context.Context) {
+	r.reexecuteLock.Lock()
+	if r.cancelReexecute != nil {
+		r.cancelReexecute()
+	}
+	reexecuteCtx, cancel := context.WithCancel(ctx)
+	r.cancelReexecute = cancel
+	r.reexecuteLock.Unlock()
+
+	r.executionTree.reexecutionStarted()
+	go func() {
+		defer r.executionTree.reexecutionComplete()
+		defer cancel()
+
+		// wait for any previous (cancelled) re-execution to finish updating the leaf
+		r.executeLock.Lock()
+		defer r.executeLock.Unlock()
+		if reexecuteCtx.Err() != nil {
+			return
+		}
+		r.reexecute(reexecuteCtx)
+	}()
+}
+
+// reexecute re-runs the query of a completed leaf run, using the current runtime dependency values
+// the parent has already been notified of completion so only the leaf events are raised
+// if the re-execution is cancelled (i.e. replaced by a later re-execution), the leaf status is not updated
+func (r *LeafRun) reexecute(ctx context.Context) {
+	if err := r.waitForRuntimeDependencies(ctx); err != nil {
+		log.Printf("[WARN] failed to resolve runtime dependencies of %s: %s", r.Name, err.Error())
+		return
+	}
+	err := r.executeQuery(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		r.Error = err
+		r.ErrorString = err.Error()
+		r.runStatus = dashboardinterfaces.DashboardRunError
+		r.executionTree.publishEvent(&dashboardevents.LeafNodeError{Node: r, Session: r.executionTree.sessionId})
+		return
+	}
+	r.Error = nil
+	r.ErrorString = ""
+	r.runStatus = dashboardinterfaces.DashboardRunComplete
+	r.executionTree.publishEvent(&dashboardevents.LeafNodeComplete{Node: r, Session: r.executionTree.sessionId})
+}
+
+func (r *LeafRun) executeQuery(ctx context.Context) error {
+	// if caching is enabled, use the cached data for this sql (unless this is a refresh)
+	ttl := leafCacheTTL()
+	if ttl > 0 && !r.executionTree.refresh {
+		if data, ok := leafCache.get(r.SQL); ok {
+			log.Printf("[TRACE] using cached data for %s", r.Name)
+			r.Data = data
+			return nil
+		}
+	}
+
+	queryResult, err := r.executionTree.client.ExecuteSync(ctx, r.SQL)
+	if err != nil {
+		return err
+	}
+	r.Data = NewLeafData(queryResult)
+	if ttl > 0 {
+		leafCache.set(r.SQL, r.Data, ttl)
+	}
+	return nil
+}
+
 // GetName implements DashboardNodeRun
 func (r *LeafRun) GetName() string {
 	return r.Name
@@ -109,9 +193,10 @@ func (r *LeafRun) GetRunStatus() dashboardinterfaces.DashboardRunStatus {
 // SetError implements DashboardNodeRun
 func (r *LeafRun) SetError(err error) {
 	r.Error = err
+	r.ErrorString = err.Error()
 	r.runStatus = dashboardinterfaces.DashboardRunError
 	// raise counter error event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeError{Node: r})
+	r.executionTree.publishEvent(&dashboardevents.LeafNodeError{Node: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 
@@ -121,7 +206,7 @@ func (r *LeafRun) SetError(err error) {
 func (r *LeafRun) SetComplete() {
 	r.runStatus = dashboardinterfaces.DashboardRunComplete
 	// raise counter complete event
-	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeComplete{Node: r})
+	r.executionTree.publishEvent(&dashboardevents.LeafNodeComplete{Node: r, Session: r.executionTree.sessionId})
 	// tell parent we are done
 	r.parent.ChildCompleteChan() <- r
 }
@@ -138,28 +223,73 @@ func (r *LeafRun) ChildrenComplete() bool {
 
 func (r *LeafRun) waitForRuntimeDependencies(ctx context.Context) error {
 	runtimeDependencies := r.DashboardNode.GetRuntimeDependencies()
+	if len(runtimeDependencies) == 0 {
+		return nil
+	}
 
 	// runtime dependencies are always (for now) dashboard inputs
-
+	// build the args populated by the dependency values
+	var args *modconfig.QueryArgs
 	for _, dependency := range runtimeDependencies {
 		// check with the top level dashboard whether the dependency is available
 		inputValue, err := r.executionTree.Root.GetRuntimeDependency(dependency)
 		if err != nil {
 			return err
 		}
-		if inputValue != nil {
-			// ok we have this one - set it on the dependency
-			dependency.Value = inputValue
+		if inputValue == nil {
+			if err := r.executionTree.waitForRuntimeDependency(ctx, dependency); err != nil {
+				return err
+			}
+			// the value is now available
+			if inputValue, err = r.executionTree.Root.GetRuntimeDependency(dependency); err != nil {
+				return err
+			}
+		}
+		if inputValue == nil || !dependency.IsArgDependency() {
 			continue
 		}
 
-		if err := r.executionTree.waitForRuntimeDependency(ctx, dependency); err != nil {
+		if args == nil {
+			args = r.getBaseArgs()
+		}
+		dependency.SetArg(args, pgEscapeString(*inputValue))
+	}
+
+	// if any args were populated, resolve the sql using them
+	if queryProvider, ok := r.DashboardNode.(modconfig.QueryProvider); ok && args != nil {
+		sql, err := r.executionTree.workspace.ResolveQuery(queryProvider, args)
+		if err != nil {
 			return err
 		}
+		r.SQL = sql
+	}
+	return nil
+}
+
+func hasArgRuntimeDependencies(resource modconfig.DashboardLeafNode) bool {
+	for _, dependency := range resource.GetRuntimeDependencies() {
+		if dependency.IsArgDependency() {
+			return true
+		}
+	}
+	return false
+}
 
-		// now populate the runtime dependency target property
-		//r.setRuntimeDependency()
+// getBaseArgs returns a copy of the args defined by the dashboard node (if any)
+func (r *LeafRun) getBaseArgs() *modconfig.QueryArgs {
+	args := modconfig.NewQueryArgs()
+	queryProvider, ok := r.DashboardNode.(modconfig.QueryProvider)
+	if !ok || queryProvider.GetArgs() == nil {
+		return args
 	}
+	for k, v := range queryProvider.GetArgs().Args {
+		args.Args[k] = v
+	}
+	args.ArgsList = append(args.ArgsList, queryProvider.GetArgs().ArgsList...)
+	return args
+}
 
-	return nil
+// pgEscapeString converts an input value to a postgres string literal
+func pgEscapeString(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
 }
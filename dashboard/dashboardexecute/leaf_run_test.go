@@ -0,0 +1,183 @@
+package dashboardexecute
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/workspace"
+)
+
+// newTestExecutionTree creates an execution tree for a dashboard with a single input 'input.i1',
+// containing a card which depends on the input (if argDependency is set, the input populates an arg of the card)
+func newTestExecutionTree(t *testing.T, ctx context.Context, argDependency bool) (*DashboardExecutionTree, *LeafRun) {
+	executionTree := &DashboardExecutionTree{
+		runs:                   make(map[string]dashboardinterfaces.DashboardNodeRun),
+		runComplete:            make(chan dashboardinterfaces.DashboardNodeRun, 1),
+		inputDataSubscriptions: make(map[string][]chan bool),
+		inputValues:            make(map[string]interface{}),
+		dashboardName:          "m1.dashboard.d1",
+		ctx:                    ctx,
+		workspace:              &workspace.Workspace{},
+	}
+
+	dashboard := &modconfig.Dashboard{}
+	if err := dashboard.SetInputs([]*modconfig.DashboardInput{{UnqualifiedName: "input.i1"}}); err != nil {
+		t.Fatal(err)
+	}
+	root := &DashboardRun{
+		Name:          "m1.dashboard.d1",
+		Status:        dashboardinterfaces.DashboardRunReady,
+		dashboardNode: dashboard,
+		parent:        executionTree,
+		executionTree: executionTree,
+		childComplete: make(chan dashboardinterfaces.DashboardNodeRun, 1),
+	}
+	executionTree.Root = root
+
+	dependency := &modconfig.RuntimeDependency{
+		PropertyPath:     &modconfig.ParsedPropertyPath{ItemType: modconfig.BlockTypeInput, Name: "i1"},
+		TargetProperties: []string{"title"},
+	}
+	if argDependency {
+		argName := "p1"
+		dependency.TargetProperties = []string{"args"}
+		dependency.ArgName = &argName
+	}
+	card := &modconfig.DashboardCard{}
+	card.AddRuntimeDependencies(dependency)
+	leafRun := &LeafRun{
+		Name:          "m1.card.c1",
+		DashboardNode: card,
+		parent:        root,
+		executionTree: executionTree,
+		runStatus:     dashboardinterfaces.DashboardRunReady,
+		SQL:           "select 1",
+	}
+	root.Children = append(root.Children, leafRun)
+	executionTree.runs[root.Name] = root
+	executionTree.runs[leafRun.Name] = leafRun
+	return executionTree, leafRun
+}
+
+func TestExecuteCancelledWaitingForInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	executionTree, leafRun := newTestExecutionTree(t, ctx, true)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executionTree.Execute(ctx)
+	}()
+	// the leaf is waiting for the input - cancel the execution
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("TestExecuteCancelledWaitingForInput FAILED : execution did not return after being cancelled")
+	}
+	if leafRun.GetRunStatus() != dashboardinterfaces.DashboardRunError {
+		t.Errorf("TestExecuteCancelledWaitingForInput FAILED : expected leaf status %s, got %s", dashboardinterfaces.DashboardRunError, leafRun.GetRunStatus())
+	}
+	if !executionTree.Root.RunComplete() {
+		t.Errorf("TestExecuteCancelledWaitingForInput FAILED : expected the root run to be complete")
+	}
+}
+
+type inputDependentRunsTest struct {
+	argDependency bool
+	runStatus     dashboardinterfaces.DashboardRunStatus
+	input         string
+	expected      int
+}
+
+var testCasesInputDependentRuns = map[string]inputDependentRunsTest{
+	"arg dependency": {
+		argDependency: true,
+		runStatus:     dashboardinterfaces.DashboardRunComplete,
+		input:         "input.i1",
+		expected:      1,
+	},
+	"other input": {
+		argDependency: true,
+		runStatus:     dashboardinterfaces.DashboardRunComplete,
+		input:         "input.i2",
+		expected:      0,
+	},
+	"not an arg dependency": {
+		argDependency: false,
+		runStatus:     dashboardinterfaces.DashboardRunComplete,
+		input:         "input.i1",
+		expected:      0,
+	},
+	// runs waiting for the input are notified by SetInputs
+	"not yet run": {
+		argDependency: true,
+		runStatus:     dashboardinterfaces.DashboardRunReady,
+		input:         "input.i1",
+		expected:      0,
+	},
+}
+
+func TestGetInputDependentRuns(t *testing.T) {
+	for name, test := range testCasesInputDependentRuns {
+		executionTree, leafRun := newTestExecutionTree(t, context.Background(), test.argDependency)
+		leafRun.runStatus = test.runStatus
+
+		res := executionTree.getInputDependentRuns(test.input)
+		if len(res) != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %d dependent runs, got %d", name, test.expected, len(res))
+		}
+	}
+}
+
+func TestReexecuteSerialised(t *testing.T) {
+	ctx := context.Background()
+	executionTree, leafRun := newTestExecutionTree(t, ctx, false)
+	executionTree.Root.Status = dashboardinterfaces.DashboardRunComplete
+	leafRun.runStatus = dashboardinterfaces.DashboardRunComplete
+	executionTree.SetInputs(map[string]interface{}{"input.i1": "a"})
+
+	var completeEvents int32
+	executionTree.workspace.RegisterDashboardEventHandler(func(event dashboardevents.DashboardEvent) {
+		if _, ok := event.(*dashboardevents.LeafNodeComplete); ok {
+			atomic.AddInt32(&completeEvents, 1)
+		}
+	})
+
+	// serve the leaf query from the cache
+	viper.Set(constants.ArgDashboardServerCacheTTL, 60)
+	defer viper.Set(constants.ArgDashboardServerCacheTTL, nil)
+	data := &LeafData{}
+	leafCache.set(leafRun.SQL, data, time.Minute)
+
+	// simulate a re-execution which is still updating the leaf
+	leafRun.executeLock.Lock()
+	leafRun.startReexecute(ctx)
+	leafRun.startReexecute(ctx)
+	if executionTree.executionComplete() {
+		t.Errorf("TestReexecuteSerialised FAILED : expected the execution to be incomplete while re-executing")
+	}
+	leafRun.executeLock.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !executionTree.executionComplete() {
+		if time.Now().After(deadline) {
+			t.Fatalf("TestReexecuteSerialised FAILED : re-execution did not complete")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the first re-execution was cancelled by the second, so only one completes
+	if events := atomic.LoadInt32(&completeEvents); events != 1 {
+		t.Errorf("TestReexecuteSerialised FAILED : expected 1 leaf complete event, got %d", events)
+	}
+	if leafRun.Data != data || leafRun.GetRunStatus() != dashboardinterfaces.DashboardRunComplete {
+		t.Errorf("TestReexecuteSerialised FAILED : expected the leaf to be complete with the re-executed data")
+	}
+}
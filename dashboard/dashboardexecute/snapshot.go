@@ -0,0 +1,119 @@
+package dashboardexecute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/workspace"
+)
+
+const snapshotSchemaVersion = "20220420"
+
+// the session id used for snapshot executions
+const snapshotSessionId = "snapshot"
+
+// DashboardSnapshot is the result of a dashboard execution
+// - the executed tree of dashboard runs (including the leaf data and any errors)
+type DashboardSnapshot struct {
+	SchemaVersion string                                 `json:"schema_version"`
+	Dashboard     string                                 `json:"dashboard"`
+	StartTime     time.Time                              `json:"start_time"`
+	EndTime       time.Time                              `json:"end_time"`
+	Status        dashboardinterfaces.DashboardRunStatus `json:"status"`
+	Root          dashboardinterfaces.DashboardNodeRun   `json:"root"`
+}
+
+// ExecuteSnapshot executes the dashboard and waits for it to complete, returning the snapshot
+func ExecuteSnapshot(ctx context.Context, dashboardName string, workspace *workspace.Workspace, client db_common.Client) (*DashboardSnapshot, error) {
+	dashboard, err := resolveDashboard(dashboardName, workspace)
+	if err != nil {
+		return nil, err
+	}
+	// use the full name of the dashboard - this is the name of the root run
+	dashboardName = dashboard.Name()
+	// there is no client to provide input values, so the dashboard would never complete
+	if err := validateSnapshotInputs(dashboard, dashboard); err != nil {
+		return nil, err
+	}
+
+	snapshot := &DashboardSnapshot{
+		SchemaVersion: snapshotSchemaVersion,
+		Dashboard:     dashboardName,
+		StartTime:     time.Now(),
+	}
+	// execute the dashboard synchronously
+	// (the execution is not associated with a client session so is not added to the executions map)
+	executionTree, err := NewReportExecutionTree(ctx, dashboardName, snapshotSessionId, client, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if err := executionTree.Execute(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if executionTree.Root.GetRunStatus() != dashboardinterfaces.DashboardRunError {
+			executionTree.Root.SetError(err)
+		}
+	}
+
+	snapshot.EndTime = time.Now()
+	snapshot.Status = executionTree.Root.GetRunStatus()
+	snapshot.Root = executionTree.Root
+	return snapshot, nil
+}
+
+// resolve the dashboard from a name of the form 'dashboard.<name>' or '<mod>.dashboard.<name>'
+func resolveDashboard(dashboardName string, workspace *workspace.Workspace) (*modconfig.Dashboard, error) {
+	parsedName, err := modconfig.ParseResourceName(dashboardName)
+	if err != nil {
+		return nil, err
+	}
+	// allow the 'dashboard.' prefix to be omitted
+	if parsedName.ItemType == "" {
+		parsedName.ItemType = modconfig.BlockTypeDashboard
+	}
+	if parsedName.ItemType != modconfig.BlockTypeDashboard {
+		return nil, fmt.Errorf("'%s' is not a dashboard", dashboardName)
+	}
+	resource, found := modconfig.GetResource(workspace, parsedName)
+	if !found {
+		return nil, fmt.Errorf("dashboard '%s' does not exist in workspace", dashboardName)
+	}
+	return resource.(*modconfig.Dashboard), nil
+}
+
+// WriteSnapshot writes the snapshot to a JSON file
+func WriteSnapshot(snapshot *DashboardSnapshot, fileName string) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// verify that all inputs which are referenced by the dashboard have a value
+func validateSnapshotInputs(dashboard *modconfig.Dashboard, item modconfig.ModTreeItem) error {
+	if leafNode, ok := item.(modconfig.DashboardLeafNode); ok {
+		for _, dependency := range leafNode.GetRuntimeDependencies() {
+			if dependency.PropertyPath.ItemType != modconfig.BlockTypeInput {
+				continue
+			}
+			inputName := dependency.PropertyPath.ToResourceName()
+			if input, ok := dashboard.GetInput(inputName); !ok || input.Value == nil {
+				return fmt.Errorf("dashboard '%s' cannot be run as a snapshot - %s depends on '%s' which has no value", dashboard.Name(), item.Name(), inputName)
+			}
+		}
+	}
+	for _, child := range item.GetChildren() {
+		if err := validateSnapshotInputs(dashboard, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+package dashboardexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const (
+	LeafAdded   = "added"
+	LeafRemoved = "removed"
+	LeafChanged = "changed"
+)
+
+// SnapshotDiff is the per-leaf difference between 2 snapshots of the same dashboard
+// only leaves which have changed are included
+type SnapshotDiff struct {
+	Dashboard    string      `json:"dashboard"`
+	PreviousTime time.Time   `json:"previous_time"`
+	CurrentTime  time.Time   `json:"current_time"`
+	Leaves       []*LeafDiff `json:"leaves"`
+}
+
+// LeafDiff is the difference between the data of a leaf node in 2 snapshots
+// depending on the node type, one of Card, Table or Chart is populated (unless the leaf was added or removed)
+type LeafDiff struct {
+	Name     string     `json:"name"`
+	NodeType string     `json:"node_type"`
+	Change   string     `json:"change"`
+	Card     *CardDiff  `json:"card,omitempty"`
+	Table    *TableDiff `json:"table,omitempty"`
+	Chart    *ChartDiff `json:"chart,omitempty"`
+}
+
+// CardDiff is the numeric change in a card value
+// if either value is not numeric, the delta is not set
+type CardDiff struct {
+	Previous interface{} `json:"previous"`
+	Current  interface{} `json:"current"`
+	Delta    *float64    `json:"delta,omitempty"`
+}
+
+// TableDiff is the rows added to and removed from a table
+// rows are matched using the key column - if the table does not have the key column, the full row is used
+type TableDiff struct {
+	KeyColumn   string          `json:"key_column,omitempty"`
+	Columns     []string        `json:"columns"`
+	AddedRows   [][]interface{} `json:"added_rows,omitempty"`
+	RemovedRows [][]interface{} `json:"removed_rows,omitempty"`
+}
+
+// ChartDiff is the change in each series of a chart
+type ChartDiff struct {
+	Series []*ChartSeriesDiff `json:"series"`
+}
+
+type ChartSeriesDiff struct {
+	Name   string            `json:"name"`
+	Points []*ChartPointDiff `json:"points"`
+}
+
+// ChartPointDiff is the change in the value of a series for a category (i.e. the chart x axis value)
+// if the category is not present in one of the snapshots, the value for that snapshot is nil
+type ChartPointDiff struct {
+	Category string   `json:"category"`
+	Previous *float64 `json:"previous"`
+	Current  *float64 `json:"current"`
+	Delta    float64  `json:"delta"`
+}
+
+// snapshotNode is the JSON form of a dashboard node run, as written to a snapshot
+type snapshotNode struct {
+	Name     string          `json:"name"`
+	NodeType string          `json:"node_type"`
+	Data     *LeafData       `json:"data"`
+	Children []*snapshotNode `json:"children"`
+}
+
+// snapshotFile is the JSON form of a DashboardSnapshot
+type snapshotFile struct {
+	Dashboard string        `json:"dashboard"`
+	EndTime   time.Time     `json:"end_time"`
+	Root      *snapshotNode `json:"root"`
+}
+
+// DiffSnapshots compares 2 JSON dashboard snapshots, returning the changes in the leaf data
+// keyColumn is the column used to match the rows of tables
+func DiffSnapshots(previousData, currentData []byte, keyColumn string) (*SnapshotDiff, error) {
+	var previous, current snapshotFile
+	if err := json.Unmarshal(previousData, &previous); err != nil {
+		return nil, fmt.Errorf("failed to read previous snapshot: %s", err.Error())
+	}
+	if err := json.Unmarshal(currentData, &current); err != nil {
+		return nil, fmt.Errorf("failed to read current snapshot: %s", err.Error())
+	}
+	if previous.Dashboard != current.Dashboard {
+		return nil, fmt.Errorf("cannot compare snapshots of different dashboards: '%s' and '%s'", previous.Dashboard, current.Dashboard)
+	}
+
+	diff := &SnapshotDiff{
+		Dashboard:    current.Dashboard,
+		PreviousTime: previous.EndTime,
+		CurrentTime:  current.EndTime,
+	}
+
+	previousLeaves := make(map[string]*snapshotNode)
+	collectLeaves(previous.Root, previousLeaves)
+	currentLeaves := make(map[string]*snapshotNode)
+	collectLeaves(current.Root, currentLeaves)
+
+	for name, currentLeaf := range currentLeaves {
+		previousLeaf, ok := previousLeaves[name]
+		if !ok {
+			diff.Leaves = append(diff.Leaves, &LeafDiff{Name: name, NodeType: currentLeaf.NodeType, Change: LeafAdded})
+			continue
+		}
+		if leafDiff := diffLeaf(previousLeaf, currentLeaf, keyColumn); leafDiff != nil {
+			diff.Leaves = append(diff.Leaves, leafDiff)
+		}
+	}
+	for name, previousLeaf := range previousLeaves {
+		if _, ok := currentLeaves[name]; !ok {
+			diff.Leaves = append(diff.Leaves, &LeafDiff{Name: name, NodeType: previousLeaf.NodeType, Change: LeafRemoved})
+		}
+	}
+	sort.Slice(diff.Leaves, func(i, j int) bool {
+		return diff.Leaves[i].Name < diff.Leaves[j].Name
+	})
+	return diff, nil
+}
+
+// collectLeaves builds a map of the nodes with data, keyed by name
+func collectLeaves(node *snapshotNode, leaves map[string]*snapshotNode) {
+	if node == nil {
+		return
+	}
+	if node.Data != nil {
+		leaves[node.Name] = node
+	}
+	for _, child := range node.Children {
+		collectLeaves(child, leaves)
+	}
+}
+
+// diffLeaf returns the difference between the leaf data, or nil if there is no difference
+func diffLeaf(previous, current *snapshotNode, keyColumn string) *LeafDiff {
+	leafDiff := &LeafDiff{Name: current.Name, NodeType: current.NodeType, Change: LeafChanged}
+	switch current.NodeType {
+	case modconfig.BlockTypeCard:
+		leafDiff.Card = diffCard(previous.Data, current.Data)
+		if leafDiff.Card == nil {
+			return nil
+		}
+	case modconfig.BlockTypeChart:
+		leafDiff.Chart = diffChart(previous.Data, current.Data)
+		if leafDiff.Chart == nil {
+			return nil
+		}
+	default:
+		leafDiff.Table = diffTable(previous.Data, current.Data, keyColumn)
+		if leafDiff.Table == nil {
+			return nil
+		}
+	}
+	return leafDiff
+}
+
+// diffCard compares the card values, which are either
+// - simple: the value of the first column
+// - formal: the value of the 'value' column
+func diffCard(previous, current *LeafData) *CardDiff {
+	previousValue := cardValue(previous)
+	currentValue := cardValue(current)
+	if diffValueKey(previousValue) == diffValueKey(currentValue) {
+		return nil
+	}
+	cardDiff := &CardDiff{Previous: previousValue, Current: currentValue}
+	previousNumber, previousOk := toNumber(previousValue)
+	currentNumber, currentOk := toNumber(currentValue)
+	if previousOk && currentOk {
+		delta := currentNumber - previousNumber
+		cardDiff.Delta = &delta
+	}
+	return cardDiff
+}
+
+func cardValue(data *LeafData) interface{} {
+	if len(data.Columns) == 0 || len(data.Rows) == 0 {
+		return nil
+	}
+	if valueIdx := data.columnIndex("value"); valueIdx != -1 {
+		return data.Rows[0][valueIdx]
+	}
+	return data.Rows[0][0]
+}
+
+// diffTable returns the rows which have been added or removed
+func diffTable(previous, current *LeafData, keyColumn string) *TableDiff {
+	tableDiff := &TableDiff{}
+	for _, c := range current.Columns {
+		tableDiff.Columns = append(tableDiff.Columns, c.Name)
+	}
+
+	// only use the key column if both tables have it
+	previousKeyIdx := previous.columnIndex(keyColumn)
+	currentKeyIdx := current.columnIndex(keyColumn)
+	if keyColumn != "" && previousKeyIdx != -1 && currentKeyIdx != -1 {
+		tableDiff.KeyColumn = keyColumn
+	}
+	rowKey := func(row []interface{}, keyIdx int) string {
+		if tableDiff.KeyColumn == "" {
+			return diffValueKey(row)
+		}
+		return diffValueKey(row[keyIdx])
+	}
+
+	// count the previous rows for each key - if the full row is used as the key, identical rows are matched in order
+	previousKeys := make(map[string]int)
+	for _, row := range previous.Rows {
+		previousKeys[rowKey(row, previousKeyIdx)]++
+	}
+	currentKeys := make(map[string]int)
+	for _, row := range current.Rows {
+		key := rowKey(row, currentKeyIdx)
+		currentKeys[key]++
+		if currentKeys[key] > previousKeys[key] {
+			tableDiff.AddedRows = append(tableDiff.AddedRows, row)
+		}
+	}
+	matched := make(map[string]int)
+	for _, row := range previous.Rows {
+		key := rowKey(row, previousKeyIdx)
+		matched[key]++
+		if matched[key] > currentKeys[key] {
+			tableDiff.RemovedRows = append(tableDiff.RemovedRows, row)
+		}
+	}
+
+	if len(tableDiff.AddedRows) == 0 && len(tableDiff.RemovedRows) == 0 {
+		return nil
+	}
+	return tableDiff
+}
+
+// diffChart compares the chart series
+// the first column provides the categories and each subsequent column is a series
+func diffChart(previous, current *LeafData) *ChartDiff {
+	previousSeries := chartSeries(previous)
+	currentSeries := chartSeries(current)
+
+	chartDiff := &ChartDiff{}
+	for _, name := range chartSeriesNames(previous, current) {
+		seriesDiff := &ChartSeriesDiff{Name: name}
+		previousPoints := previousSeries[name]
+		currentPoints := currentSeries[name]
+		for _, category := range chartCategories(previous, current) {
+			previousValue := previousPoints[category]
+			currentValue := currentPoints[category]
+			var previousNumber, currentNumber float64
+			if previousValue != nil {
+				previousNumber = *previousValue
+			}
+			if currentValue != nil {
+				currentNumber = *currentValue
+			}
+			// include the point if the value has changed, or the category was added or removed
+			if previousNumber == currentNumber && (previousValue == nil) == (currentValue == nil) {
+				continue
+			}
+			seriesDiff.Points = append(seriesDiff.Points, &ChartPointDiff{
+				Category: category,
+				Previous: previousValue,
+				Current:  currentValue,
+				Delta:    currentNumber - previousNumber,
+			})
+		}
+		if len(seriesDiff.Points) > 0 {
+			chartDiff.Series = append(chartDiff.Series, seriesDiff)
+		}
+	}
+
+	if len(chartDiff.Series) == 0 {
+		return nil
+	}
+	return chartDiff
+}
+
+// chartSeries returns a map of the values of each series, keyed by category
+func chartSeries(data *LeafData) map[string]map[string]*float64 {
+	res := make(map[string]map[string]*float64)
+	for columnIdx := 1; columnIdx < len(data.Columns); columnIdx++ {
+		points := make(map[string]*float64)
+		for _, row := range data.Rows {
+			if value, ok := toNumber(row[columnIdx]); ok {
+				points[chartCategory(row[0])] = &value
+			}
+		}
+		res[data.Columns[columnIdx].Name] = points
+	}
+	return res
+}
+
+// the series names of the current chart, followed by any series which were removed
+func chartSeriesNames(previous, current *LeafData) []string {
+	var res []string
+	lookup := make(map[string]bool)
+	for _, data := range []*LeafData{current, previous} {
+		for columnIdx := 1; columnIdx < len(data.Columns); columnIdx++ {
+			name := data.Columns[columnIdx].Name
+			if !lookup[name] {
+				lookup[name] = true
+				res = append(res, name)
+			}
+		}
+	}
+	return res
+}
+
+// the categories of the current chart, followed by any categories which were removed
+func chartCategories(previous, current *LeafData) []string {
+	var res []string
+	lookup := make(map[string]bool)
+	for _, data := range []*LeafData{current, previous} {
+		if len(data.Columns) == 0 {
+			continue
+		}
+		for _, row := range data.Rows {
+			category := chartCategory(row[0])
+			if !lookup[category] {
+				lookup[category] = true
+				res = append(res, category)
+			}
+		}
+	}
+	return res
+}
+
+func chartCategory(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return diffValueKey(value)
+}
+
+func (d *LeafData) columnIndex(name string) int {
+	for i, c := range d.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffValueKey returns a string representation of a value, used to compare values
+func diffValueKey(value interface{}) string {
+	res, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(res)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		res, err := strconv.ParseFloat(v, 64)
+		return res, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,86 @@
+package dashboardexecute
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type diffSnapshotsTest struct {
+	previous  string
+	current   string
+	keyColumn string
+	expected  interface{}
+}
+
+func leafSnapshot(nodeType, data string) string {
+	return `{"dashboard":"m1.dashboard.d1","root":{"name":"m1.dashboard.d1","children":[{"name":"leaf1","node_type":"` + nodeType + `","data":` + data + `}]}}`
+}
+
+var testCasesDiffSnapshots = map[string]diffSnapshotsTest{
+	"card delta": {
+		previous: leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[10]]}`),
+		current:  leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[13]]}`),
+		expected: `[{"name":"leaf1","node_type":"card","change":"changed","card":{"previous":10,"current":13,"delta":3}}]`,
+	},
+	"formal card delta": {
+		previous: leafSnapshot("card", `{"columns":[{"name":"label"},{"name":"value"}],"rows":[["Count",10]]}`),
+		current:  leafSnapshot("card", `{"columns":[{"name":"label"},{"name":"value"}],"rows":[["Count",4]]}`),
+		expected: `[{"name":"leaf1","node_type":"card","change":"changed","card":{"previous":10,"current":4,"delta":-6}}]`,
+	},
+	"card unchanged": {
+		previous: leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[10]]}`),
+		current:  leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[10]]}`),
+		expected: `null`,
+	},
+	"table key column": {
+		previous:  leafSnapshot("table", `{"columns":[{"name":"id"},{"name":"state"}],"rows":[["i-1","running"],["i-2","running"]]}`),
+		current:   leafSnapshot("table", `{"columns":[{"name":"id"},{"name":"state"}],"rows":[["i-1","stopped"],["i-3","running"]]}`),
+		keyColumn: "id",
+		expected:  `[{"name":"leaf1","node_type":"table","change":"changed","table":{"key_column":"id","columns":["id","state"],"added_rows":[["i-3","running"]],"removed_rows":[["i-2","running"]]}}]`,
+	},
+	"table full row": {
+		previous: leafSnapshot("table", `{"columns":[{"name":"id"},{"name":"state"}],"rows":[["i-1","running"],["i-2","running"]]}`),
+		current:  leafSnapshot("table", `{"columns":[{"name":"id"},{"name":"state"}],"rows":[["i-1","stopped"],["i-2","running"]]}`),
+		expected: `[{"name":"leaf1","node_type":"table","change":"changed","table":{"columns":["id","state"],"added_rows":[["i-1","stopped"]],"removed_rows":[["i-1","running"]]}}]`,
+	},
+	"chart series": {
+		previous: leafSnapshot("chart", `{"columns":[{"name":"region"},{"name":"count"}],"rows":[["us-east-1",5],["us-west-1",2]]}`),
+		current:  leafSnapshot("chart", `{"columns":[{"name":"region"},{"name":"count"}],"rows":[["us-east-1",8],["us-west-1",2]]}`),
+		expected: `[{"name":"leaf1","node_type":"chart","change":"changed","chart":{"series":[{"name":"count","points":[{"category":"us-east-1","previous":5,"current":8,"delta":3}]}]}}]`,
+	},
+	"added leaf": {
+		previous: `{"dashboard":"m1.dashboard.d1","root":{"name":"m1.dashboard.d1"}}`,
+		current:  leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[10]]}`),
+		expected: `[{"name":"leaf1","node_type":"card","change":"added"}]`,
+	},
+	"different dashboards": {
+		previous: `{"dashboard":"m1.dashboard.d2","root":{"name":"m1.dashboard.d2"}}`,
+		current:  leafSnapshot("card", `{"columns":[{"name":"Count"}],"rows":[[10]]}`),
+		expected: "ERROR",
+	},
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	for name, test := range testCasesDiffSnapshots {
+		diff, err := DiffSnapshots([]byte(test.previous), []byte(test.current), test.keyColumn)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+
+		res, _ := json.Marshal(diff.Leaves)
+		var expected, actual interface{}
+		json.Unmarshal([]byte(test.expected.(string)), &expected)
+		json.Unmarshal(res, &actual)
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %s, \ngot:\n %s\n", name, test.expected, string(res))
+		}
+	}
+}
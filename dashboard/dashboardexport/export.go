@@ -0,0 +1,86 @@
+package dashboardexport
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/version"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+const htmlTemplateName = "dashboard.html.tmpl"
+
+// htmlRenderContext is the data passed to the html template
+type htmlRenderContext struct {
+	SteampipeVersion string
+	Snapshot         *dashboardexecute.DashboardSnapshot
+	Root             *htmlNode
+}
+
+// IsSupportedExportFile returns whether the given file can be exported to
+// (only self-contained html is supported at present)
+func IsSupportedExportFile(fileName string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	return ext == ".html" || ext == ".htm"
+}
+
+// ExportHTML renders the snapshot as a self-contained static html file
+// the data is inlined so the file may be viewed without a dashboard server
+func ExportHTML(snapshot *dashboardexecute.DashboardSnapshot, fileName string) error {
+	var buf bytes.Buffer
+	if err := renderHTML(&buf, snapshot); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, buf.Bytes(), 0644)
+}
+
+func renderHTML(w io.Writer, snapshot *dashboardexecute.DashboardSnapshot) error {
+	if snapshot.Root == nil {
+		return fmt.Errorf("snapshot of %s has no results", snapshot.Dashboard)
+	}
+	tmpl, err := template.New(htmlTemplateName).Funcs(htmlTemplateFuncs).ParseFS(templateFS, "templates/*")
+	if err != nil {
+		return err
+	}
+	renderContext := &htmlRenderContext{
+		SteampipeVersion: version.SteampipeVersion.String(),
+		Snapshot:         snapshot,
+		Root:             newHtmlNode(snapshot.Root),
+	}
+	return tmpl.Execute(w, renderContext)
+}
+
+var htmlTemplateFuncs = template.FuncMap{
+	"gridSpan": gridSpan,
+	"imageSrc": imageSrc,
+}
+
+// gridSpan converts a dashboard width to a css grid column span - a width of 0 uses the full width
+func gridSpan(width int) int {
+	if width <= 0 || width > 12 {
+		return 12
+	}
+	return width
+}
+
+// matches the media type and parameters of a data uri containing an image
+var dataImageRegex = regexp.MustCompile(`^data:image/[a-zA-Z0-9.+-]+(;[a-zA-Z0-9.+=-]+)*,`)
+
+// imageSrc returns the source of an image - data uris containing an image are trusted, so they are
+// not replaced by the html template, as any other unsafe url is
+func imageSrc(src string) interface{} {
+	if dataImageRegex.MatchString(src) {
+		return template.URL(src)
+	}
+	return src
+}
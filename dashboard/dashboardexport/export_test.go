@@ -0,0 +1,142 @@
+package dashboardexport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type markdownToHtmlTest struct {
+	markdown string
+	expected string
+}
+
+var testCasesMarkdownToHtml = map[string]markdownToHtmlTest{
+	"heading": {
+		markdown: "# Title",
+		expected: "<h1>Title</h1>\n",
+	},
+	"heading and paragraph": {
+		markdown: "## Title\nline 1\nline 2\n\nline 3",
+		expected: "<h2>Title</h2>\n<p>line 1 line 2</p>\n<p>line 3</p>\n",
+	},
+	"not a heading": {
+		markdown: "#hashtag",
+		expected: "<p>#hashtag</p>\n",
+	},
+	"html is escaped": {
+		markdown: "<script>alert(1)</script>",
+		expected: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+	},
+}
+
+func TestMarkdownToHtml(t *testing.T) {
+	for name, test := range testCasesMarkdownToHtml {
+		res := string(markdownToHtml(test.markdown))
+		if res != test.expected {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %q, \ngot:\n %q\n", name, test.expected, res)
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	leafData := func(columns []string, rows ...[]interface{}) *dashboardexecute.LeafData {
+		data := &dashboardexecute.LeafData{Rows: rows}
+		for _, c := range columns {
+			data.Columns = append(data.Columns, &dashboardexecute.LeafDataColumnType{Name: c})
+		}
+		return data
+	}
+	root := &dashboardexecute.DashboardRun{
+		Name:     "m1.dashboard.d1",
+		Title:    "Test Dashboard",
+		NodeType: modconfig.BlockTypeDashboard,
+		Children: []dashboardinterfaces.DashboardNodeRun{
+			&dashboardexecute.LeafRun{
+				NodeType:      modconfig.BlockTypeCard,
+				DashboardNode: &modconfig.DashboardCard{},
+				Data:          leafData([]string{"Buckets"}, []interface{}{42}),
+			},
+			&dashboardexecute.LeafRun{
+				NodeType:      modconfig.BlockTypeTable,
+				DashboardNode: &modconfig.DashboardTable{},
+				Data:          leafData([]string{"name", "region"}, []interface{}{"<bucket>", "us-east-1"}),
+			},
+			&dashboardexecute.LeafRun{
+				NodeType:      modconfig.BlockTypeChart,
+				DashboardNode: &modconfig.DashboardChart{},
+				Data:          leafData([]string{"region", "total"}, []interface{}{"us-east-1", int64(3)}),
+			},
+			&dashboardexecute.LeafRun{
+				NodeType:      modconfig.BlockTypeImage,
+				DashboardNode: &modconfig.DashboardImage{},
+				Data:          leafData([]string{"src"}, []interface{}{"data:image/png;base64,iVBORw0KGgo="}),
+			},
+			&dashboardexecute.LeafRun{
+				NodeType:      modconfig.BlockTypeImage,
+				DashboardNode: &modconfig.DashboardImage{},
+				Data:          leafData([]string{"src"}, []interface{}{"data:text/html;base64,PHNjcmlwdD4="}),
+			},
+		},
+	}
+	snapshot := &dashboardexecute.DashboardSnapshot{Dashboard: root.Name, Root: root}
+
+	var buf bytes.Buffer
+	if err := renderHTML(&buf, snapshot); err != nil {
+		t.Fatalf("renderHTML failed: %s", err.Error())
+	}
+	res := buf.String()
+	for _, expected := range []string{
+		"<h1>Test Dashboard</h1>",
+		`<div class="card-label">Buckets</div>`,
+		`<div class="card-value">42</div>`,
+		"<td>&lt;bucket&gt;</td>",
+		"<svg class=\"chart\"",
+		"<title>total: 3</title>",
+		`<img src="data:image/png;base64,iVBORw0KGgo="`,
+		`<img src="#ZgotmplZ"`,
+	} {
+		if !strings.Contains(res, expected) {
+			t.Errorf("rendered html does not contain %s", expected)
+		}
+	}
+}
+
+type buildChartSVGTest struct {
+	values []float64
+	// the expected y and height of each column
+	expected []string
+}
+
+var testCasesBuildChartSVG = map[string]buildChartSVGTest{
+	"positive values": {
+		values:   []float64{1, 2},
+		expected: []string{`y="100.0" width="220.0" height="100.0"`, `y="0.0" width="220.0" height="200.0"`},
+	},
+	"negative values": {
+		values:   []float64{-1, 1},
+		expected: []string{`y="100.0" width="220.0" height="100.0"`, `y="0.0" width="220.0" height="100.0"`},
+	},
+	"only negative values": {
+		values:   []float64{-1, -2},
+		expected: []string{`y="0.0" width="220.0" height="100.0"`, `y="0.0" width="220.0" height="200.0"`},
+	},
+}
+
+func TestBuildChartSVG(t *testing.T) {
+	for name, test := range testCasesBuildChartSVG {
+		svg := string(buildChartSVG(defaultSeriesType, []string{"a", "b"}, []htmlChartSeries{{Name: "s1", Values: test.values}}))
+		for _, expected := range test.expected {
+			if !strings.Contains(svg, expected) {
+				t.Errorf("Test: '%s'' FAILED : svg does not contain %s\n%s", name, expected, svg)
+			}
+		}
+		if strings.Contains(svg, `height="-`) {
+			t.Errorf("Test: '%s'' FAILED : svg contains a negative height\n%s", name, svg)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package dashboardexport
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const (
+	chartWidth        = 600
+	chartHeight       = 240
+	chartLabelHeight  = 40
+	chartAxisWidth    = 50
+	chartTypeLine     = "line"
+	defaultSeriesType = "column"
+)
+
+var seriesColors = []string{"#2f5f95", "#e06c3c", "#3c9e5f", "#c4414f", "#8250df", "#d4a72c", "#5c8ea6", "#949595"}
+
+type htmlChart struct {
+	Type   string
+	SVG    template.HTML
+	Legend []htmlChartSeries
+}
+
+type htmlChartSeries struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+// newHtmlChart builds an svg chart from the leaf data
+// the first column provides the category labels and each subsequent numeric column is a series
+// line charts are drawn as lines - all other chart types are drawn as grouped columns
+func newHtmlChart(r *dashboardexecute.LeafRun) *htmlChart {
+	chart := &htmlChart{Type: defaultSeriesType}
+	var chartNode *modconfig.DashboardChart
+	if c, ok := r.DashboardNode.(*modconfig.DashboardChart); ok {
+		chartNode = c
+		if c.Type != nil {
+			chart.Type = *c.Type
+		}
+	}
+	if r.Data == nil || len(r.Data.Columns) < 2 {
+		return chart
+	}
+
+	categories := make([]string, len(r.Data.Rows))
+	for i, row := range r.Data.Rows {
+		categories[i] = formatValue(row[0])
+	}
+	for columnIdx, column := range r.Data.Columns[1:] {
+		series := htmlChartSeries{
+			Name:   column.Name,
+			Color:  seriesColors[columnIdx%len(seriesColors)],
+			Values: make([]float64, len(r.Data.Rows)),
+		}
+		if chartNode != nil {
+			for _, s := range chartNode.SeriesList {
+				if s.Name == column.Name && s.Color != nil {
+					series.Color = *s.Color
+				}
+			}
+		}
+		for rowIdx, row := range r.Data.Rows {
+			series.Values[rowIdx] = toFloat(row[columnIdx+1])
+		}
+		chart.Legend = append(chart.Legend, series)
+	}
+
+	chart.SVG = buildChartSVG(chart.Type, categories, chart.Legend)
+	return chart
+}
+
+// the values are scaled over a range which always includes zero - columns are drawn from the zero baseline,
+// so negative values are drawn below it
+func buildChartSVG(chartType string, categories []string, series []htmlChartSeries) template.HTML {
+	minValue, maxValue := 0.0, 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			minValue = math.Min(minValue, v)
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	if minValue == maxValue {
+		maxValue = 1
+	}
+
+	plotWidth := float64(chartWidth - chartAxisWidth)
+	plotHeight := float64(chartHeight - chartLabelHeight)
+	categoryWidth := plotWidth / math.Max(float64(len(categories)), 1)
+	y := func(v float64) float64 { return plotHeight - ((v-minValue)/(maxValue-minValue))*plotHeight }
+	baseline := y(0)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`, chartWidth, chartHeight))
+	// axes (the category axis is drawn at the zero baseline) and max/min value labels
+	sb.WriteString(fmt.Sprintf(`<line class="axis" x1="%d" y1="0" x2="%d" y2="%.1f"/>`, chartAxisWidth, chartAxisWidth, plotHeight))
+	sb.WriteString(fmt.Sprintf(`<line class="axis" x1="%d" y1="%.1f" x2="%d" y2="%.1f"/>`, chartAxisWidth, baseline, chartWidth, baseline))
+	sb.WriteString(fmt.Sprintf(`<text class="axis-label" x="%d" y="12" text-anchor="end">%s</text>`, chartAxisWidth-4, template.HTMLEscapeString(formatFloat(maxValue))))
+	if minValue < 0 {
+		sb.WriteString(fmt.Sprintf(`<text class="axis-label" x="%d" y="%.1f" text-anchor="end">%s</text>`, chartAxisWidth-4, plotHeight, template.HTMLEscapeString(formatFloat(minValue))))
+	}
+
+	for i, category := range categories {
+		x := float64(chartAxisWidth) + categoryWidth*float64(i)
+		sb.WriteString(fmt.Sprintf(`<text class="axis-label" x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, x+categoryWidth/2, plotHeight+16, template.HTMLEscapeString(category)))
+	}
+
+	if chartType == chartTypeLine {
+		for _, s := range series {
+			points := make([]string, len(s.Values))
+			for i, v := range s.Values {
+				points[i] = fmt.Sprintf("%.1f,%.1f", float64(chartAxisWidth)+categoryWidth*(float64(i)+0.5), y(v))
+			}
+			sb.WriteString(fmt.Sprintf(`<polyline fill="none" stroke-width="2" stroke="%s" points="%s"><title>%s</title></polyline>`, template.HTMLEscapeString(s.Color), strings.Join(points, " "), template.HTMLEscapeString(s.Name)))
+		}
+	} else {
+		// leave a gap between the column groups
+		barWidth := categoryWidth * 0.8 / math.Max(float64(len(series)), 1)
+		for seriesIdx, s := range series {
+			for i, v := range s.Values {
+				x := float64(chartAxisWidth) + categoryWidth*float64(i) + categoryWidth*0.1 + barWidth*float64(seriesIdx)
+				// the column extends from the baseline - up for positive values, down for negative values
+				top := math.Min(y(v), baseline)
+				height := math.Abs(y(v) - baseline)
+				sb.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+					x, top, barWidth, height, template.HTMLEscapeString(s.Color), template.HTMLEscapeString(s.Name), template.HTMLEscapeString(formatFloat(v))))
+			}
+		}
+	}
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
+
+// toFloat converts a numeric data value to a float - non numeric values are treated as zero
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	f, err := strconv.ParseFloat(typehelpers.ToString(value), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,244 @@
+package dashboardexport
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// htmlNode is the view of an executed dashboard node used by the html template
+type htmlNode struct {
+	Name     string
+	Title    string
+	NodeType string
+	Width    int
+	Error    string
+	Children []*htmlNode
+
+	// only one of these is populated, depending on the node type
+	Card  *htmlCard
+	Table *htmlTable
+	Chart *htmlChart
+	Text  template.HTML
+	Image *htmlImage
+	Check *controlexecute.StatusSummary
+}
+
+type htmlCard struct {
+	Label string
+	Value string
+	Type  string
+}
+
+type htmlTable struct {
+	Columns []string
+	Rows    [][]string
+}
+
+type htmlImage struct {
+	Src string
+	Alt string
+}
+
+func newHtmlNode(run dashboardinterfaces.DashboardNodeRun) *htmlNode {
+	switch r := run.(type) {
+	case *dashboardexecute.DashboardRun:
+		return newHtmlContainerNode(r.Name, r.Title, r.NodeType, r.Width, r.ErrorString, r.Children)
+	case *dashboardexecute.DashboardContainerRun:
+		return newHtmlContainerNode(r.Name, r.Title, r.NodeType, r.Width, r.ErrorString, r.Children)
+	case *dashboardexecute.CheckRun:
+		node := &htmlNode{Name: r.Name, Title: r.Title, NodeType: r.NodeType, Width: r.Width, Error: r.ErrorString}
+		if r.ControlExecutionTree != nil && r.ControlExecutionTree.Root != nil {
+			node.Check = &r.ControlExecutionTree.Root.Summary.Status
+		}
+		return node
+	case *dashboardexecute.LeafRun:
+		return newHtmlLeafNode(r)
+	}
+	return &htmlNode{Name: run.GetName()}
+}
+
+func newHtmlContainerNode(name, title, nodeType string, width int, err string, children []dashboardinterfaces.DashboardNodeRun) *htmlNode {
+	node := &htmlNode{Name: name, Title: title, NodeType: nodeType, Width: width, Error: err}
+	for _, child := range children {
+		node.Children = append(node.Children, newHtmlNode(child))
+	}
+	return node
+}
+
+func newHtmlLeafNode(r *dashboardexecute.LeafRun) *htmlNode {
+	node := &htmlNode{Name: r.Name, Title: r.Title, NodeType: r.NodeType, Width: r.Width, Error: r.ErrorString}
+	switch r.NodeType {
+	case modconfig.BlockTypeCard:
+		node.Card = newHtmlCard(r)
+	case modconfig.BlockTypeChart:
+		node.Chart = newHtmlChart(r)
+	case modconfig.BlockTypeText:
+		node.Text = newHtmlText(r)
+	case modconfig.BlockTypeImage:
+		node.Image = newHtmlImage(r)
+	case modconfig.BlockTypeInput:
+		// inputs cannot be changed in a static export - the values used are shown in the dependent nodes
+	default:
+		// tables, hierarchies and any other node with data are rendered as a table
+		node.Table = newHtmlTable(r)
+	}
+	return node
+}
+
+// newHtmlCard builds a card from the leaf data, which may either be
+// - simple: a single column, where the column name is the label and the value is the value
+// - formal: columns named 'label', 'value' and (optionally) 'type'
+func newHtmlCard(r *dashboardexecute.LeafRun) *htmlCard {
+	card := &htmlCard{}
+	if c, ok := r.DashboardNode.(*modconfig.DashboardCard); ok {
+		card.Type = typehelpers.SafeString(c.Type)
+	}
+	if r.Data == nil || len(r.Data.Columns) == 0 || len(r.Data.Rows) == 0 {
+		return card
+	}
+	row := r.Data.Rows[0]
+	if valueIdx := columnIndex(r.Data, "value"); valueIdx != -1 {
+		card.Value = formatValue(row[valueIdx])
+		if labelIdx := columnIndex(r.Data, "label"); labelIdx != -1 {
+			card.Label = formatValue(row[labelIdx])
+		}
+		if typeIdx := columnIndex(r.Data, "type"); typeIdx != -1 {
+			card.Type = formatValue(row[typeIdx])
+		}
+		return card
+	}
+	card.Label = r.Data.Columns[0].Name
+	card.Value = formatValue(row[0])
+	return card
+}
+
+func newHtmlTable(r *dashboardexecute.LeafRun) *htmlTable {
+	if r.Data == nil {
+		return nil
+	}
+	// exclude any columns which the table hides
+	hidden := make(map[string]bool)
+	if t, ok := r.DashboardNode.(*modconfig.DashboardTable); ok {
+		for _, c := range t.ColumnList {
+			if typehelpers.SafeString(c.Display) == "none" {
+				hidden[c.Name] = true
+			}
+		}
+	}
+
+	table := &htmlTable{}
+	var columnIdxs []int
+	for i, c := range r.Data.Columns {
+		if hidden[c.Name] {
+			continue
+		}
+		table.Columns = append(table.Columns, c.Name)
+		columnIdxs = append(columnIdxs, i)
+	}
+	for _, row := range r.Data.Rows {
+		tableRow := make([]string, len(columnIdxs))
+		for i, idx := range columnIdxs {
+			tableRow[i] = formatValue(row[idx])
+		}
+		table.Rows = append(table.Rows, tableRow)
+	}
+	return table
+}
+
+func newHtmlText(r *dashboardexecute.LeafRun) template.HTML {
+	text, ok := r.DashboardNode.(*modconfig.DashboardText)
+	if !ok {
+		return ""
+	}
+	value := typehelpers.SafeString(text.Value)
+	// html text is defined by the mod author so is trusted
+	if typehelpers.SafeString(text.Type) == "html" {
+		return template.HTML(value)
+	}
+	return markdownToHtml(value)
+}
+
+func newHtmlImage(r *dashboardexecute.LeafRun) *htmlImage {
+	image := &htmlImage{}
+	if i, ok := r.DashboardNode.(*modconfig.DashboardImage); ok {
+		image.Src = typehelpers.SafeString(i.Src)
+		image.Alt = typehelpers.SafeString(i.Alt)
+	}
+	// if the image has a query, the first row provides the source (and optionally the alt text)
+	if r.Data != nil && len(r.Data.Columns) > 0 && len(r.Data.Rows) > 0 {
+		row := r.Data.Rows[0]
+		srcIdx := columnIndex(r.Data, "src")
+		if srcIdx == -1 {
+			srcIdx = 0
+		}
+		image.Src = formatValue(row[srcIdx])
+		if altIdx := columnIndex(r.Data, "alt"); altIdx != -1 {
+			image.Alt = formatValue(row[altIdx])
+		}
+	}
+	return image
+}
+
+func columnIndex(data *dashboardexecute.LeafData, name string) int {
+	for i, c := range data.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// markdownToHtml converts a (minimal) subset of markdown to html - headings and paragraphs
+// the text is escaped so any embedded html is displayed as text
+func markdownToHtml(markdown string) template.HTML {
+	var sb strings.Builder
+	var paragraph []string
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString(fmt.Sprintf("<p>%s</p>\n", template.HTMLEscapeString(strings.Join(paragraph, " "))))
+			paragraph = nil
+		}
+	}
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flushParagraph()
+			continue
+		}
+		if level := headingLevel(line); level > 0 {
+			flushParagraph()
+			heading := strings.TrimSpace(line[level:])
+			sb.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, template.HTMLEscapeString(heading), level))
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	flushParagraph()
+	return template.HTML(sb.String())
+}
+
+// headingLevel returns the markdown heading level of the line, or 0 if it is not a heading
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && level < 6 && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
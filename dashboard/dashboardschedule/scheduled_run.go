@@ -0,0 +1,147 @@
+package dashboardschedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+)
+
+// ScheduledRun is the execution of a single target of a schedule
+type ScheduledRun struct {
+	Id       string `json:"id"`
+	Schedule string `json:"schedule"`
+	Target   string `json:"target"`
+	// either 'dashboard' or 'benchmark'
+	TargetType string                                 `json:"target_type"`
+	StartTime  time.Time                              `json:"start_time"`
+	EndTime    *time.Time                             `json:"end_time,omitempty"`
+	Status     dashboardinterfaces.DashboardRunStatus `json:"status"`
+	Error      string                                 `json:"error,omitempty"`
+	// the control status counts (only set for benchmark targets)
+	Summary *controlexecute.StatusSummary `json:"summary,omitempty"`
+}
+
+// ScheduledRunResult is the format of the result files written to the results directory
+// the result is a dashboard snapshot or a benchmark execution tree, depending on the target type
+type ScheduledRunResult struct {
+	Run    *ScheduledRun   `json:"run"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+func resultFilePath(resultsDir, runId string) string {
+	return filepath.Join(resultsDir, fmt.Sprintf("%s.json", runId))
+}
+
+// the run is also written to a separate file, so the run history can be loaded without reading the results
+func runFilePath(resultsDir, runId string) string {
+	return filepath.Join(resultsDir, fmt.Sprintf("%s%s", runId, runFileSuffix))
+}
+
+const runFileSuffix = ".run.json"
+
+// writeRunResult writes the run and its result to the results directory
+func writeRunResult(resultsDir string, run *ScheduledRun, result interface{}) error {
+	runResult := &ScheduledRunResult{Run: run}
+	if result != nil {
+		resultData, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		runResult.Result = resultData
+	}
+	data, err := json.MarshalIndent(runResult, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(resultFilePath(resultsDir, run.Id), data, 0644); err != nil {
+		return err
+	}
+	// write the run file last - the run is only loaded if its result has been written
+	runData, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(runFilePath(resultsDir, run.Id), runData, 0644)
+}
+
+// readRunResult reads the result file of the given run
+func readRunResult(resultsDir, runId string) (*ScheduledRunResult, error) {
+	data, err := os.ReadFile(resultFilePath(resultsDir, runId))
+	if err != nil {
+		return nil, err
+	}
+	var runResult ScheduledRunResult
+	if err := json.Unmarshal(data, &runResult); err != nil {
+		return nil, err
+	}
+	return &runResult, nil
+}
+
+// deleteRunResult removes the run and result files of the given run
+func deleteRunResult(resultsDir, runId string) error {
+	if err := os.Remove(runFilePath(resultsDir, runId)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(resultFilePath(resultsDir, runId)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// loadRunHistory reads all the runs in the results directory, sorted by start time
+// only the run files are read - files which cannot be parsed are skipped
+func loadRunHistory(resultsDir string) ([]*ScheduledRun, error) {
+	entries, err := os.ReadDir(resultsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var runs []*ScheduledRun
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), runFileSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(resultsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var run ScheduledRun
+		if err := json.Unmarshal(data, &run); err != nil || run.Id == "" {
+			continue
+		}
+		runs = append(runs, &run)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartTime.Before(runs[j].StartTime)
+	})
+	return runs, nil
+}
+
+// expiredRuns splits the runs (which are in order of start time) into the oldest completed runs which exceed
+// the retention count, and the runs to retain
+// if retention is zero, all runs are retained
+func expiredRuns(runs []*ScheduledRun, retention int) (expired, retained []*ScheduledRun) {
+	if retention <= 0 || len(runs) <= retention {
+		return nil, runs
+	}
+	expireCount := len(runs) - retention
+	for _, run := range runs {
+		// runs which are still executing are never expired
+		if len(expired) < expireCount && run.EndTime != nil {
+			expired = append(expired, run)
+			continue
+		}
+		retained = append(retained, run)
+	}
+	return expired, retained
+}
@@ -0,0 +1,172 @@
+package dashboardschedule
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+)
+
+func TestLoadRunHistory(t *testing.T) {
+	resultsDir := t.TempDir()
+	start := time.Now()
+
+	// write the runs out of order
+	var expectedIds []string
+	for i := 2; i >= 0; i-- {
+		run := &ScheduledRun{
+			Id:         uuid.NewString(),
+			Schedule:   "nightly",
+			Target:     "dashboard.d1",
+			TargetType: "dashboard",
+			StartTime:  start.Add(time.Duration(i) * time.Hour),
+			Status:     dashboardinterfaces.DashboardRunComplete,
+		}
+		if err := writeRunResult(resultsDir, run, map[string]string{"dashboard": "dashboard.d1"}); err != nil {
+			t.Fatalf("TestLoadRunHistory FAILED : failed to write result: %s", err.Error())
+		}
+		expectedIds = append([]string{run.Id}, expectedIds...)
+	}
+
+	runs, err := loadRunHistory(resultsDir)
+	if err != nil {
+		t.Fatalf("TestLoadRunHistory FAILED : %s", err.Error())
+	}
+	if len(runs) != len(expectedIds) {
+		t.Fatalf("TestLoadRunHistory FAILED : expected %d runs, got %d", len(expectedIds), len(runs))
+	}
+	for i, run := range runs {
+		if run.Id != expectedIds[i] {
+			t.Errorf("TestLoadRunHistory FAILED : expected run %d to be %s, got %s", i, expectedIds[i], run.Id)
+		}
+	}
+
+	// verify the result is persisted
+	runResult, err := readRunResult(resultsDir, runs[0].Id)
+	if err != nil {
+		t.Fatalf("TestLoadRunHistory FAILED : %s", err.Error())
+	}
+	var result map[string]string
+	if err := json.Unmarshal(runResult.Result, &result); err != nil || result["dashboard"] != "dashboard.d1" {
+		t.Errorf("TestLoadRunHistory FAILED : unexpected result %s", string(runResult.Result))
+	}
+}
+
+func TestLoadRunHistoryIncompleteResult(t *testing.T) {
+	resultsDir := t.TempDir()
+	run := &ScheduledRun{Id: uuid.NewString(), StartTime: time.Now()}
+	if err := writeRunResult(resultsDir, run, nil); err != nil {
+		t.Fatalf("TestLoadRunHistoryIncompleteResult FAILED : failed to write result: %s", err.Error())
+	}
+	// a result without a run file is not loaded
+	if err := os.Remove(runFilePath(resultsDir, run.Id)); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := loadRunHistory(resultsDir)
+	if err != nil {
+		t.Fatalf("TestLoadRunHistoryIncompleteResult FAILED : %s", err.Error())
+	}
+	if len(runs) != 0 {
+		t.Errorf("TestLoadRunHistoryIncompleteResult FAILED : expected no runs, got %d", len(runs))
+	}
+}
+
+type expiredRunsTest struct {
+	// the runs, identified by id - runs with an id starting with 'running' have not completed
+	runs      []string
+	retention int
+	expired   []string
+	retained  []string
+}
+
+var testCasesExpiredRuns = map[string]expiredRunsTest{
+	"within retention": {
+		runs:      []string{"r1", "r2"},
+		retention: 2,
+		retained:  []string{"r1", "r2"},
+	},
+	"exceeds retention": {
+		runs:      []string{"r1", "r2", "r3"},
+		retention: 1,
+		expired:   []string{"r1", "r2"},
+		retained:  []string{"r3"},
+	},
+	"running runs are not expired": {
+		runs:      []string{"running1", "r2", "r3"},
+		retention: 2,
+		expired:   []string{"r2"},
+		retained:  []string{"running1", "r3"},
+	},
+	"no retention": {
+		runs:      []string{"r1", "r2", "r3"},
+		retention: 0,
+		retained:  []string{"r1", "r2", "r3"},
+	},
+}
+
+func TestExpiredRuns(t *testing.T) {
+	runIds := func(runs []*ScheduledRun) []string {
+		var res []string
+		for _, run := range runs {
+			res = append(res, run.Id)
+		}
+		return res
+	}
+	for name, test := range testCasesExpiredRuns {
+		var runs []*ScheduledRun
+		endTime := time.Now()
+		for _, id := range test.runs {
+			run := &ScheduledRun{Id: id}
+			if !strings.HasPrefix(id, "running") {
+				run.EndTime = &endTime
+			}
+			runs = append(runs, run)
+		}
+
+		expired, retained := expiredRuns(runs, test.retention)
+		if !reflect.DeepEqual(runIds(expired), test.expired) {
+			t.Errorf("Test: '%s'' FAILED : expected expired runs %v, got %v", name, test.expired, runIds(expired))
+		}
+		if !reflect.DeepEqual(runIds(retained), test.retained) {
+			t.Errorf("Test: '%s'' FAILED : expected retained runs %v, got %v", name, test.retained, runIds(retained))
+		}
+	}
+}
+
+func TestPruneRuns(t *testing.T) {
+	resultsDir := t.TempDir()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		endTime := start.Add(time.Duration(i) * time.Hour)
+		run := &ScheduledRun{Id: uuid.NewString(), StartTime: endTime, EndTime: &endTime}
+		if err := writeRunResult(resultsDir, run, nil); err != nil {
+			t.Fatalf("TestPruneRuns FAILED : failed to write result: %s", err.Error())
+		}
+	}
+
+	// the retention is applied to the loaded history
+	scheduler, err := NewScheduler(nil, resultsDir, 1, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("TestPruneRuns FAILED : %s", err.Error())
+	}
+	runs := scheduler.Runs()
+	if len(runs) != 1 || !runs[0].StartTime.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("TestPruneRuns FAILED : expected only the most recent run to be retained, got %v", runs)
+	}
+	entries, err := os.ReadDir(resultsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("TestPruneRuns FAILED : expected the files of the expired runs to be deleted, got %d files", len(entries))
+	}
+	if _, err := scheduler.GetRunResult(runs[0].Id); err != nil {
+		t.Errorf("TestPruneRuns FAILED : expected the result of the retained run to be readable: %s", err.Error())
+	}
+}
@@ -0,0 +1,256 @@
+package dashboardschedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/workspace"
+)
+
+// ScheduleInfo describes a configured schedule
+type ScheduleInfo struct {
+	Name    string     `json:"name"`
+	Cron    string     `json:"cron"`
+	Targets []string   `json:"targets"`
+	NextRun *time.Time `json:"next_run,omitempty"`
+}
+
+// RunUpdateHandler is called when a scheduled run starts and when it completes
+type RunUpdateHandler func(run *ScheduledRun)
+
+// Scheduler executes the targets of the configured schedules while the dashboard server is running
+// the result of each run is written to the results directory, and the history of runs is loaded from there on startup
+// only the most recent runs are retained - the results of older runs are deleted
+type Scheduler struct {
+	schedules  map[string]*modconfig.Schedule
+	resultsDir string
+	// the maximum number of runs to retain - if zero, all runs are retained
+	retention   int
+	workspace   *workspace.Workspace
+	client      db_common.Client
+	onRunUpdate RunUpdateHandler
+
+	cron *cron.Cron
+	// the runs, in order of start time
+	runs []*ScheduledRun
+	// the names of the schedules which are currently running
+	running map[string]bool
+	lock    sync.Mutex
+}
+
+func NewScheduler(schedules map[string]*modconfig.Schedule, resultsDir string, retention int, workspace *workspace.Workspace, client db_common.Client, onRunUpdate RunUpdateHandler) (*Scheduler, error) {
+	runs, err := loadRunHistory(resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scheduled run history: %s", err.Error())
+	}
+	scheduler := &Scheduler{
+		schedules:   schedules,
+		resultsDir:  resultsDir,
+		retention:   retention,
+		workspace:   workspace,
+		client:      client,
+		onRunUpdate: onRunUpdate,
+		cron:        cron.New(),
+		runs:        runs,
+		running:     make(map[string]bool),
+	}
+	// the retention may have been reduced since the runs were written
+	scheduler.pruneRuns()
+	return scheduler, nil
+}
+
+// Start starts the cron scheduler - it is stopped when the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) error {
+	for _, schedule := range s.schedules {
+		cronSchedule, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			return fmt.Errorf("invalid cron expression '%s' for schedule '%s': %s", schedule.Cron, schedule.Name, err.Error())
+		}
+		// take a copy of the loop var for the closure
+		schedule := schedule
+		s.cron.Schedule(cronSchedule, cron.FuncJob(func() { s.runSchedule(ctx, schedule) }))
+	}
+	s.cron.Start()
+
+	go func() {
+		<-ctx.Done()
+		s.cron.Stop()
+	}()
+	return nil
+}
+
+// Schedules returns the configured schedules, sorted by name
+func (s *Scheduler) Schedules() []ScheduleInfo {
+	var res []ScheduleInfo
+	for _, schedule := range s.schedules {
+		info := ScheduleInfo{
+			Name:    schedule.Name,
+			Cron:    schedule.Cron,
+			Targets: schedule.Targets,
+		}
+		if cronSchedule, err := cron.ParseStandard(schedule.Cron); err == nil {
+			nextRun := cronSchedule.Next(time.Now())
+			info.NextRun = &nextRun
+		}
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// Runs returns a copy of the run history, in order of start time
+func (s *Scheduler) Runs() []ScheduledRun {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	res := make([]ScheduledRun, len(s.runs))
+	for i, run := range s.runs {
+		res[i] = *run
+	}
+	return res
+}
+
+// GetRunResult returns the persisted result of the given run
+func (s *Scheduler) GetRunResult(runId string) (*ScheduledRunResult, error) {
+	if _, err := uuid.Parse(runId); err != nil {
+		return nil, fmt.Errorf("invalid run id '%s'", runId)
+	}
+	return readRunResult(s.resultsDir, runId)
+}
+
+func (s *Scheduler) runSchedule(ctx context.Context, schedule *modconfig.Schedule) {
+	s.lock.Lock()
+	if s.running[schedule.Name] {
+		s.lock.Unlock()
+		log.Printf("[WARN] schedule %s is still running - skipping this run", schedule.Name)
+		return
+	}
+	s.running[schedule.Name] = true
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.running, schedule.Name)
+		s.lock.Unlock()
+	}()
+
+	log.Printf("[TRACE] running schedule %s", schedule.Name)
+	// execute the targets sequentially
+	for _, target := range schedule.Targets {
+		if ctx.Err() != nil {
+			return
+		}
+		s.runTarget(ctx, schedule, target)
+	}
+}
+
+func (s *Scheduler) runTarget(ctx context.Context, schedule *modconfig.Schedule, target string) {
+	run := &ScheduledRun{
+		Id:        uuid.NewString(),
+		Schedule:  schedule.Name,
+		Target:    target,
+		StartTime: time.Now(),
+		Status:    dashboardinterfaces.DashboardRunReady,
+	}
+	if parsedName, err := modconfig.ParseResourceName(target); err == nil {
+		run.TargetType = parsedName.ItemType
+	}
+	s.lock.Lock()
+	s.runs = append(s.runs, run)
+	s.lock.Unlock()
+	s.raiseRunUpdate(run)
+
+	result, err := s.executeTarget(ctx, run)
+
+	s.lock.Lock()
+	endTime := time.Now()
+	run.EndTime = &endTime
+	if err != nil {
+		run.Status = dashboardinterfaces.DashboardRunError
+		run.Error = err.Error()
+	}
+	runCopy := *run
+	s.lock.Unlock()
+
+	// write the result without holding the lock - the result may be large
+	if writeErr := writeRunResult(s.resultsDir, &runCopy, result); writeErr != nil {
+		log.Printf("[WARN] failed to write result of scheduled run of %s: %s", target, writeErr.Error())
+	}
+	s.raiseRunUpdate(run)
+	s.pruneRuns()
+}
+
+// pruneRuns removes the oldest completed runs which exceed the retention count, and deletes their results
+func (s *Scheduler) pruneRuns() {
+	s.lock.Lock()
+	var expired []*ScheduledRun
+	expired, s.runs = expiredRuns(s.runs, s.retention)
+	s.lock.Unlock()
+
+	for _, run := range expired {
+		if err := deleteRunResult(s.resultsDir, run.Id); err != nil {
+			log.Printf("[WARN] failed to delete result of scheduled run %s: %s", run.Id, err.Error())
+		}
+	}
+}
+
+// executeTarget executes the dashboard or benchmark, returning the result to persist
+// the run status is set from the result
+func (s *Scheduler) executeTarget(ctx context.Context, run *ScheduledRun) (interface{}, error) {
+	switch run.TargetType {
+	case modconfig.BlockTypeDashboard:
+		snapshot, err := dashboardexecute.ExecuteSnapshot(ctx, run.Target, s.workspace, s.client)
+		if err != nil {
+			return nil, err
+		}
+		s.setStatus(run, snapshot.Status)
+		return snapshot, nil
+
+	case modconfig.BlockTypeBenchmark:
+		executionTree, err := controlexecute.NewExecutionTree(ctx, s.workspace, s.client, run.Target)
+		if err != nil {
+			return nil, err
+		}
+		executionTree.Execute(ctx)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		s.lock.Lock()
+		summary := executionTree.Root.Summary.Status
+		run.Summary = &summary
+		s.lock.Unlock()
+		s.setStatus(run, dashboardinterfaces.DashboardRunComplete)
+		return executionTree, nil
+	}
+	return nil, fmt.Errorf("'%s' is not a dashboard or benchmark", run.Target)
+}
+
+func (s *Scheduler) setStatus(run *ScheduledRun, status dashboardinterfaces.DashboardRunStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	run.Status = status
+}
+
+// raiseRunUpdate invokes the update handler with a copy of the run
+func (s *Scheduler) raiseRunUpdate(run *ScheduledRun) {
+	if s.onRunUpdate == nil {
+		return
+	}
+	s.lock.Lock()
+	runCopy := *run
+	s.lock.Unlock()
+	s.onRunUpdate(&runCopy)
+}
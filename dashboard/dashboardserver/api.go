@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os/exec"
 	"path"
 	"runtime"
@@ -37,12 +38,37 @@ func openBrowser(url string) error {
 	return exec.Command(cmd, args...).Start()
 }
 
-func StartAPI(ctx context.Context, webSocket *melody.Melody) *http.Server {
+// getBrowserUrl returns the url to open the dashboard - if a token is required, it is passed as a query parameter
+func getBrowserUrl(port int, tlsEnabled bool, auth *ServerAuth) string {
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	browserUrl := fmt.Sprintf("%s://localhost:%d", scheme, port)
+	if auth.tokenEnabled() {
+		browserUrl = fmt.Sprintf("%s/?%s=%s", browserUrl, authTokenQueryParam, url.QueryEscape(auth.Token))
+	}
+	return browserUrl
+}
+
+func StartAPI(ctx context.Context, webSocket *melody.Melody, server *Server) *http.Server {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	// only add the Recovery middleware
 	router.Use(gin.Recovery())
 
+	certFile, keyFile, tlsEnabled, err := getTLSCertificate()
+	if err != nil {
+		outputError(ctx, err)
+		return nil
+	}
+
+	// authentication must be added before any routes so it applies to both the static assets and the websocket
+	auth := NewServerAuth()
+	if auth.Enabled() {
+		router.Use(auth.Middleware(tlsEnabled))
+	}
+
 	assetsDirectory := filepaths.EnsureDashboardAssetsDir()
 
 	router.Use(static.Serve("/", static.LocalFile(assetsDirectory, true)))
@@ -51,6 +77,8 @@ func StartAPI(ctx context.Context, webSocket *melody.Melody) *http.Server {
 		webSocket.HandleRequest(c.Writer, c.Request)
 	})
 
+	server.registerRestRoutes(router)
+
 	router.NoRoute(func(c *gin.Context) {
 		c.File(path.Join(assetsDirectory, "index.html"))
 	})
@@ -68,12 +96,22 @@ func StartAPI(ctx context.Context, webSocket *melody.Melody) *http.Server {
 
 	go func() {
 		// service connections
-		if err := srv.ListenAndServe(); err != nil {
+		var err error
+		if tlsEnabled {
+			err = srv.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
 			log.Printf("listen: %s\n", err)
 		}
 	}()
 
-	_ = openBrowser(fmt.Sprintf("http://localhost:%d", dashboardServerPort))
+	if !tlsEnabled && dashboardServerListen == "" {
+		outputWarning(ctx, "Dashboard server is listening on the network without TLS - enable it in the 'dashboard' options")
+	}
+
+	_ = openBrowser(getBrowserUrl(dashboardServerPort, tlsEnabled, auth))
 	outputReady(ctx, fmt.Sprintf("Dashboard server started on %d and listening on %s", dashboardServerPort, viper.GetString(constants.ArgDashboardServerListen)))
 	<-ctx.Done()
 	log.Println("Shutdown Server ...")
@@ -0,0 +1,99 @@
+package dashboardserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+)
+
+const (
+	authTokenQueryParam = "token"
+	authTokenCookie     = "steampipe_dashboard_token"
+	authRealm           = `Basic realm="Steampipe Dashboard"`
+)
+
+// ServerAuth is the authentication required to access the dashboard server
+// if both a token and basic auth credentials are set, either may be used
+type ServerAuth struct {
+	Token    string
+	Username string
+	Password string
+}
+
+func NewServerAuth() *ServerAuth {
+	return &ServerAuth{
+		Token:    viper.GetString(constants.ArgDashboardServerAuthToken),
+		Username: viper.GetString(constants.ArgDashboardServerAuthUsername),
+		Password: viper.GetString(constants.ArgDashboardServerAuthPassword),
+	}
+}
+
+func (a *ServerAuth) Enabled() bool {
+	return a.tokenEnabled() || a.basicEnabled()
+}
+
+func (a *ServerAuth) tokenEnabled() bool {
+	return a.Token != ""
+}
+
+func (a *ServerAuth) basicEnabled() bool {
+	return a.Username != ""
+}
+
+// Middleware returns a gin middleware which rejects unauthenticated requests
+// this is applied to all routes, including the static assets and the websocket upgrade
+//
+// the token may be passed as a bearer token, a 'token' query parameter or a cookie
+// - when passed as a query parameter (e.g. in the url opened in the browser) the cookie is set,
+// so the subsequent asset and websocket requests made by the browser are authenticated
+func (a *ServerAuth) Middleware(secure bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a.tokenEnabled() {
+			if queryToken := c.Query(authTokenQueryParam); queryToken != "" && a.tokenMatches(queryToken) {
+				c.SetSameSite(http.SameSiteStrictMode)
+				c.SetCookie(authTokenCookie, queryToken, 0, "/", "", secure, true)
+				c.Next()
+				return
+			}
+			if a.tokenMatches(bearerToken(c.Request)) {
+				c.Next()
+				return
+			}
+			if cookieToken, err := c.Cookie(authTokenCookie); err == nil && a.tokenMatches(cookieToken) {
+				c.Next()
+				return
+			}
+		}
+		if a.basicEnabled() {
+			if username, password, ok := c.Request.BasicAuth(); ok && a.basicMatches(username, password) {
+				c.Next()
+				return
+			}
+			// prompt the browser for credentials
+			c.Header("WWW-Authenticate", authRealm)
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ApiErrorResponse{Error: "unauthorized"})
+	}
+}
+
+func (a *ServerAuth) tokenMatches(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) == 1
+}
+
+func (a *ServerAuth) basicMatches(username, password string) bool {
+	usernameMatches := subtle.ConstantTimeCompare([]byte(username), []byte(a.Username)) == 1
+	passwordMatches := subtle.ConstantTimeCompare([]byte(password), []byte(a.Password)) == 1
+	return usernameMatches && passwordMatches
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if prefix := "Bearer "; strings.HasPrefix(header, prefix) {
+		return strings.TrimPrefix(header, prefix)
+	}
+	return ""
+}
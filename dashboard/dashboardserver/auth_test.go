@@ -0,0 +1,76 @@
+package dashboardserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authMiddlewareTest struct {
+	auth     *ServerAuth
+	setup    func(r *http.Request)
+	expected int
+}
+
+var testCasesAuthMiddleware = map[string]authMiddlewareTest{
+	"no credentials": {
+		auth:     &ServerAuth{Token: "t1"},
+		setup:    func(r *http.Request) {},
+		expected: http.StatusUnauthorized,
+	},
+	"bearer token": {
+		auth:     &ServerAuth{Token: "t1"},
+		setup:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer t1") },
+		expected: http.StatusOK,
+	},
+	"wrong bearer token": {
+		auth:     &ServerAuth{Token: "t1"},
+		setup:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer t2") },
+		expected: http.StatusUnauthorized,
+	},
+	"query token": {
+		auth:     &ServerAuth{Token: "t1"},
+		setup:    func(r *http.Request) { r.URL.RawQuery = "token=t1" },
+		expected: http.StatusOK,
+	},
+	"cookie token": {
+		auth:     &ServerAuth{Token: "t1"},
+		setup:    func(r *http.Request) { r.AddCookie(&http.Cookie{Name: authTokenCookie, Value: "t1"}) },
+		expected: http.StatusOK,
+	},
+	"basic auth": {
+		auth:     &ServerAuth{Username: "u1", Password: "p1"},
+		setup:    func(r *http.Request) { r.SetBasicAuth("u1", "p1") },
+		expected: http.StatusOK,
+	},
+	"wrong basic auth password": {
+		auth:     &ServerAuth{Username: "u1", Password: "p1"},
+		setup:    func(r *http.Request) { r.SetBasicAuth("u1", "p2") },
+		expected: http.StatusUnauthorized,
+	},
+	"basic auth when token and basic enabled": {
+		auth:     &ServerAuth{Token: "t1", Username: "u1", Password: "p1"},
+		setup:    func(r *http.Request) { r.SetBasicAuth("u1", "p1") },
+		expected: http.StatusOK,
+	},
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for name, test := range testCasesAuthMiddleware {
+		router := gin.New()
+		router.Use(test.auth.Middleware(false))
+		router.GET("/ws", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		test.setup(request)
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expected, recorder.Code)
+		}
+	}
+}
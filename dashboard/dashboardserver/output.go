@@ -8,8 +8,8 @@ import (
 )
 
 const (
-	errorPrefix = "[ Error   ]"
-	//warningPrefix = "[ Warning ]"
+	errorPrefix   = "[ Error   ]"
+	warningPrefix = "[ Warning ]"
 	messagePrefix = "[ Message ]"
 	readyPrefix   = "[ Ready   ]"
 	waitPrefix    = "[ Wait    ]"
@@ -27,6 +27,10 @@ func outputError(ctx context.Context, err error) {
 	output(ctx, color.RedString(errorPrefix), err)
 }
 
+func outputWarning(ctx context.Context, msg string) {
+	output(ctx, color.YellowString(warningPrefix), msg)
+}
+
 func outputReady(ctx context.Context, msg string) {
 	output(ctx, color.GreenString(readyPrefix), msg)
 }
@@ -0,0 +1,178 @@
+package dashboardserver
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const restApiPrefix = "/api/v1"
+
+type ApiResource struct {
+	FullName    string `json:"full_name"`
+	ShortName   string `json:"short_name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Mod         string `json:"mod,omitempty"`
+}
+
+type ApiExecuteRequest struct {
+	InputValues map[string]interface{} `json:"input_values"`
+	// if set, the leaf data cache is bypassed
+	Refresh bool `json:"refresh"`
+}
+
+type ApiExecuteResponse struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type ApiErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// registerRestRoutes adds the REST API routes to the router
+//
+// GET  /api/v1/dashboards                   - list the top level dashboards
+// GET  /api/v1/benchmarks                   - list the benchmarks
+// GET  /api/v1/queries                      - list the queries
+// POST /api/v1/dashboards/:name/execute     - start an execution of the dashboard
+// GET  /api/v1/dashboards/:name/execution   - get the current (or most recent) execution tree of the dashboard
+// POST /api/v1/benchmarks/:name/execute     - start an execution of the benchmark
+// GET  /api/v1/benchmarks/:name/execution   - get the current (or most recent) execution tree of the benchmark
+//
+// while an execution is running, only its status is returned by the execution endpoints
+func (s *Server) registerRestRoutes(router gin.IRouter) {
+	api := router.Group(restApiPrefix)
+
+	api.GET("/dashboards", s.listDashboards)
+	api.GET("/benchmarks", s.listBenchmarks)
+	api.GET("/queries", s.listQueries)
+	api.POST("/dashboards/:name/execute", s.executeDashboard)
+	api.GET("/dashboards/:name/execution", s.getDashboardExecution)
+	api.POST("/benchmarks/:name/execute", s.executeBenchmark)
+	api.GET("/benchmarks/:name/execution", s.getBenchmarkExecution)
+}
+
+func (s *Server) listDashboards(c *gin.Context) {
+	var items []modconfig.ModTreeItem
+	for _, dashboard := range s.workspace.GetResourceMaps().Dashboards {
+		if dashboard.IsTopLevel {
+			items = append(items, dashboard)
+		}
+	}
+	c.JSON(http.StatusOK, buildApiResources(items))
+}
+
+func (s *Server) listBenchmarks(c *gin.Context) {
+	var items []modconfig.ModTreeItem
+	for _, benchmark := range s.workspace.GetResourceMaps().Benchmarks {
+		items = append(items, benchmark)
+	}
+	c.JSON(http.StatusOK, buildApiResources(items))
+}
+
+func (s *Server) listQueries(c *gin.Context) {
+	var items []modconfig.ModTreeItem
+	for _, query := range s.workspace.GetResourceMaps().Queries {
+		items = append(items, query)
+	}
+	c.JSON(http.StatusOK, buildApiResources(items))
+}
+
+func (s *Server) executeDashboard(c *gin.Context) {
+	dashboardName := c.Param("name")
+	if _, ok := s.workspace.GetResourceMaps().Dashboards[dashboardName]; !ok {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: "dashboard not found: " + dashboardName})
+		return
+	}
+	var request ApiExecuteRequest
+	// the body is optional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, ApiErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	if err := dashboardexecute.StartDashboardNode(s.context, restApiSessionId(dashboardName), dashboardName, request.InputValues, request.Refresh, s.workspace, s.dbClient); err != nil {
+		c.JSON(http.StatusConflict, ApiErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, ApiExecuteResponse{Name: dashboardName, Status: "running"})
+}
+
+func (s *Server) getDashboardExecution(c *gin.Context) {
+	dashboardName := c.Param("name")
+	root, complete, ok := dashboardexecute.GetDashboardExecution(restApiSessionId(dashboardName))
+	if !ok {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: "no execution found for dashboard: " + dashboardName})
+		return
+	}
+	// the tree is being updated while the execution is running, so it cannot safely be serialised until it completes
+	if !complete {
+		c.JSON(http.StatusOK, ApiExecuteResponse{Name: dashboardName, Status: "running"})
+		return
+	}
+	c.JSON(http.StatusOK, root)
+}
+
+func (s *Server) executeBenchmark(c *gin.Context) {
+	benchmarkName := c.Param("name")
+	if _, ok := s.workspace.GetResourceMaps().Benchmarks[benchmarkName]; !ok {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: "benchmark not found: " + benchmarkName})
+		return
+	}
+
+	if err := dashboardexecute.ExecuteBenchmark(s.context, benchmarkName, s.workspace, s.dbClient); err != nil {
+		c.JSON(http.StatusConflict, ApiErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, ApiExecuteResponse{Name: benchmarkName, Status: "running"})
+}
+
+func (s *Server) getBenchmarkExecution(c *gin.Context) {
+	benchmarkName := c.Param("name")
+	execution, ok := dashboardexecute.GetBenchmarkExecution(benchmarkName)
+	if !ok {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: "no execution found for benchmark: " + benchmarkName})
+		return
+	}
+	c.JSON(http.StatusOK, execution)
+}
+
+func buildApiResources(items []modconfig.ModTreeItem) []ApiResource {
+	// resource maps may contain the same resource under multiple keys - dedupe by name
+	resourceMap := make(map[string]ApiResource, len(items))
+	for _, item := range items {
+		resource := ApiResource{
+			FullName:    item.Name(),
+			Title:       item.GetTitle(),
+			Description: item.GetDescription(),
+		}
+		if parsedName, err := modconfig.ParseResourceName(item.Name()); err == nil {
+			resource.ShortName = parsedName.Name
+		}
+		if mod := item.GetMod(); mod != nil {
+			resource.Mod = mod.Name()
+		}
+		resourceMap[resource.FullName] = resource
+	}
+
+	res := make([]ApiResource, 0, len(resourceMap))
+	for _, resource := range resourceMap {
+		res = append(res, resource)
+	}
+	// sort for a stable response
+	sort.Slice(res, func(i, j int) bool { return res[i].FullName < res[j].FullName })
+	return res
+}
+
+// restApiSessionId returns the execution session id used for REST API executions of the given dashboard
+// - each dashboard has a single API execution, which is shared by all API callers
+func restApiSessionId(dashboardName string) string {
+	return "api." + dashboardName
+}
@@ -0,0 +1,45 @@
+package dashboardserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestBuildApiResources(t *testing.T) {
+	q1 := &modconfig.Query{FullName: "m1.query.q1", ShortName: "q1"}
+	q2 := &modconfig.Query{FullName: "m1.query.q2", ShortName: "q2"}
+	// the same query may be present under both its full and unqualified name
+	res := buildApiResources([]modconfig.ModTreeItem{q2, q1, q2})
+
+	expected := []ApiResource{
+		{FullName: "m1.query.q1", ShortName: "q1"},
+		{FullName: "m1.query.q2", ShortName: "q2"},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("TestBuildApiResources FAILED : \nexpected:\n %v, \ngot:\n %v\n", expected, res)
+	}
+}
+
+func TestGetDashboardExecutionNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	(&Server{}).registerRestRoutes(router)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, restApiPrefix+"/dashboards/m1.dashboard.d1/execution", nil)
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("TestGetDashboardExecutionNotFound FAILED : expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	var response ApiErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Error == "" {
+		t.Errorf("TestGetDashboardExecutionNotFound FAILED : expected an error response, got %s", recorder.Body.String())
+	}
+}
@@ -8,13 +8,20 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
 	typeHelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardexecute"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/dashboard/dashboardschedule"
 	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/filepaths"
+	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/webhooks"
 	"github.com/turbot/steampipe/workspace"
 	"gopkg.in/olahol/melody.v1"
 )
@@ -51,6 +58,8 @@ type Server struct {
 	dashboardClients map[*melody.Session]*DashboardClientInfo
 	webSocket        *melody.Melody
 	workspace        *workspace.Workspace
+	scheduler        *dashboardschedule.Scheduler
+	notifier         *webhooks.Notifier
 }
 
 type ErrorPayload struct {
@@ -64,7 +73,16 @@ type ExecutionPayload struct {
 }
 
 type DashboardClientInfo struct {
+	// the id of the session - dashboard events are sent to the session whose id matches the event
+	SessionId string
 	Dashboard *string
+	// the input values for the selected dashboard, keyed by input name
+	DashboardInputs map[string]interface{}
+	// the context of the current execution of the selected dashboard
+	// this is cancelled when the session selects another dashboard or disconnects,
+	// which cancels any in-flight queries of the execution
+	executionContext context.Context
+	cancelExecution  context.CancelFunc
 }
 
 func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Workspace) (*Server, error) {
@@ -88,6 +106,25 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 	w.RegisterDashboardEventHandler(server.HandleWorkspaceUpdate)
 	err := w.SetupWatcher(ctx, dbClient, func(c context.Context, e error) {})
 	outputMessage(ctx, "Workspace loaded")
+	if err != nil {
+		return server, err
+	}
+
+	if steampipeconfig.GlobalConfig == nil {
+		return server, nil
+	}
+	// create the notifier for any configured webhooks
+	if len(steampipeconfig.GlobalConfig.Webhooks) > 0 {
+		server.notifier, err = webhooks.NewNotifier(steampipeconfig.GlobalConfig.Webhooks)
+		if err != nil {
+			return server, err
+		}
+		w.RegisterDashboardEventHandler(server.handleWebhookEvent)
+	}
+	// create the scheduler for any configured schedules
+	if len(steampipeconfig.GlobalConfig.Schedules) > 0 {
+		server.scheduler, err = dashboardschedule.NewScheduler(steampipeconfig.GlobalConfig.Schedules, filepaths.EnsureDashboardResultsDir(), viper.GetInt(constants.ArgDashboardServerResultRetention), w, dbClient, server.HandleScheduledRunUpdate)
+	}
 
 	return server, err
 }
@@ -144,6 +181,51 @@ func buildAvailableDashboardsPayload(workspaceResources *modconfig.WorkspaceReso
 	return json.Marshal(payload)
 }
 
+func buildScheduledRunsPayload(scheduler *dashboardschedule.Scheduler) ([]byte, error) {
+	payload := ScheduledRunsPayload{
+		Action: "scheduled_runs",
+	}
+	// if there are no schedules, return an empty timeline
+	if scheduler != nil {
+		payload.Schedules = scheduler.Schedules()
+		payload.Runs = scheduler.Runs()
+	}
+	return json.Marshal(payload)
+}
+
+func buildScheduledRunPayload(runResult *dashboardschedule.ScheduledRunResult) ([]byte, error) {
+	payload := ScheduledRunPayload{
+		Action: "scheduled_run",
+		Run:    runResult.Run,
+		Result: runResult.Result,
+	}
+	return json.Marshal(payload)
+}
+
+func buildScheduledRunUpdatedPayload(run *dashboardschedule.ScheduledRun) ([]byte, error) {
+	payload := ScheduledRunPayload{
+		Action: "scheduled_run_updated",
+		Run:    run,
+	}
+	return json.Marshal(payload)
+}
+
+func buildSnapshotDiffPayload(diff *dashboardexecute.SnapshotDiff) ([]byte, error) {
+	payload := SnapshotDiffPayload{
+		Action: "snapshot_diff",
+		Diff:   diff,
+	}
+	return json.Marshal(payload)
+}
+
+func buildSnapshotDiffErrorPayload(diffError error) ([]byte, error) {
+	payload := ErrorPayload{
+		Action: "snapshot_diff_error",
+		Error:  diffError.Error(),
+	}
+	return json.Marshal(payload)
+}
+
 func buildWorkspaceErrorPayload(e *dashboardevents.WorkspaceError) ([]byte, error) {
 	payload := ErrorPayload{
 		Action: "workspace_error",
@@ -212,7 +294,12 @@ func getDashboardsInterestedInResourceChanges(dashboardsBeingWatched []string, e
 // Start starts the API server
 func (s *Server) Start() {
 	go s.Init(s.context)
-	go StartAPI(s.context, s.webSocket)
+	go StartAPI(s.context, s.webSocket, s)
+	if s.scheduler != nil {
+		if err := s.scheduler.Start(s.context); err != nil {
+			outputError(s.context, err)
+		}
+	}
 }
 
 // Shutdown stops the API server
@@ -255,6 +342,12 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 		s.webSocket.Broadcast(payload)
 		outputError(s.context, e.Error)
 
+	case *dashboardevents.WorkspaceChanged:
+		log.Println("[TRACE] Got workspace changed event")
+		// named query definitions may have changed - the cached leaf data is keyed by the
+		// 'execute' sql of the prepared statement, so would not reflect the change
+		dashboardexecute.ClearLeafCache()
+
 	case *dashboardevents.ExecutionStarted:
 		log.Println("[TRACE] Got execution started event", *e)
 		payload, payloadError = buildExecutionStartedPayload(e)
@@ -262,14 +355,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 			return
 		}
 		dashboardName := e.DashboardNode.GetName()
-		s.mutex.Lock()
-		for session, dashboardClientInfo := range s.dashboardClients {
-			// If this session is interested in this dashboard, broadcast to it
-			if (dashboardClientInfo.Dashboard != nil) && *dashboardClientInfo.Dashboard == dashboardName {
-				session.Write(payload)
-			}
-		}
-		s.mutex.Unlock()
+		s.writeToSession(e.Session, payload)
 		outputWait(s.context, fmt.Sprintf("Dashboard execution started: %s", dashboardName))
 
 	case *dashboardevents.LeafNodeError:
@@ -281,15 +367,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 		if payloadError != nil {
 			return
 		}
-		paths := e.Node.GetPath()
-		s.mutex.Lock()
-		for session, dashboardClientInfo := range s.dashboardClients {
-			// If this session is interested in this dashboard, broadcast to it
-			if (dashboardClientInfo.Dashboard != nil) && helpers.StringSliceContains(paths, *dashboardClientInfo.Dashboard) {
-				session.Write(payload)
-			}
-		}
-		s.mutex.Unlock()
+		s.writeToSession(e.Session, payload)
 
 	case *dashboardevents.LeafNodeComplete:
 		log.Println("[TRACE] Got leaf node complete event", *e)
@@ -297,15 +375,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 		if payloadError != nil {
 			return
 		}
-		paths := e.Node.GetPath()
-		s.mutex.Lock()
-		for session, dashboardClientInfo := range s.dashboardClients {
-			// If this session is interested in this dashboard, broadcast to it
-			if (dashboardClientInfo.Dashboard != nil) && helpers.StringSliceContains(paths, *dashboardClientInfo.Dashboard) {
-				session.Write(payload)
-			}
-		}
-		s.mutex.Unlock()
+		s.writeToSession(e.Session, payload)
 
 	case *dashboardevents.DashboardChanged:
 		log.Println("[TRACE] Got dashboard changed event", *e)
@@ -392,7 +462,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 
 		for _, changedDashboardName := range changedDashboardNames {
 			if helpers.StringSliceContains(dashboardssBeingWatched, changedDashboardName) {
-				dashboardexecute.ExecuteDashboardNode(s.context, changedDashboardName, s.workspace, s.dbClient)
+				s.reexecuteDashboard(changedDashboardName)
 			}
 		}
 
@@ -408,7 +478,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 
 		for _, newDashboardName := range newDashboardNames {
 			if helpers.StringSliceContains(dashboardssBeingWatched, newDashboardName) {
-				dashboardexecute.ExecuteDashboardNode(s.context, newDashboardName, s.workspace, s.dbClient)
+				s.reexecuteDashboard(newDashboardName)
 			}
 		}
 
@@ -425,18 +495,28 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 			return
 		}
 		dashboardName := e.Dashboard.GetName()
-		s.mutex.Lock()
-		for session, dashboardClientInfo := range s.dashboardClients {
-			// If this session is interested in this dashboard, broadcast to it
-			if (dashboardClientInfo.Dashboard != nil) && *dashboardClientInfo.Dashboard == dashboardName {
-				session.Write(payload)
-			}
-		}
-		s.mutex.Unlock()
+		s.writeToSession(e.Session, payload)
 		outputReady(s.context, fmt.Sprintf("Execution complete: %s", dashboardName))
 	}
 }
 
+// HandleScheduledRunUpdate sends the updated scheduled run to all clients, so they may update the run timeline
+func (s *Server) HandleScheduledRunUpdate(run *dashboardschedule.ScheduledRun) {
+	payload, err := buildScheduledRunUpdatedPayload(run)
+	if err != nil {
+		panic(fmt.Errorf("error building payload for scheduled run update: %v", err))
+	}
+	s.webSocket.Broadcast(payload)
+	if run.EndTime == nil {
+		outputWait(s.context, fmt.Sprintf("Scheduled run started: %s (%s)", run.Target, run.Schedule))
+	} else {
+		outputReady(s.context, fmt.Sprintf("Scheduled run complete: %s (%s)", run.Target, run.Schedule))
+		if s.notifier != nil {
+			s.handleScheduledRunWebhookEvent(run)
+		}
+	}
+}
+
 func (s *Server) Init(ctx context.Context) {
 	// Return list of dashboards on connect
 	s.webSocket.HandleConnect(func(session *melody.Session) {
@@ -467,11 +547,72 @@ func (s *Server) Init(ctx context.Context) {
 					panic(fmt.Errorf("error building payload for get_available_dashboards: %v", err))
 				}
 				session.Write(payload)
+			case "get_scheduled_runs":
+				payload, err := buildScheduledRunsPayload(s.scheduler)
+				if err != nil {
+					panic(fmt.Errorf("error building payload for get_scheduled_runs: %v", err))
+				}
+				session.Write(payload)
+			case "get_scheduled_run":
+				if s.scheduler == nil {
+					return
+				}
+				runResult, err := s.scheduler.GetRunResult(request.Payload.RunId)
+				if err != nil {
+					log.Printf("[WARN] failed to read result of scheduled run %s: %s", request.Payload.RunId, err.Error())
+					return
+				}
+				payload, err := buildScheduledRunPayload(runResult)
+				if err != nil {
+					panic(fmt.Errorf("error building payload for get_scheduled_run: %v", err))
+				}
+				session.Write(payload)
+			case "compare_snapshots":
+				dashboardClientInfo := s.getSession(session)
+				diff, err := s.compareSnapshots(dashboardClientInfo, request.Payload)
+				if err != nil {
+					log.Printf("[WARN] failed to compare snapshots: %s", err.Error())
+					payload, err := buildSnapshotDiffErrorPayload(err)
+					if err != nil {
+						panic(fmt.Errorf("error building payload for compare_snapshots: %v", err))
+					}
+					session.Write(payload)
+					return
+				}
+				payload, err := buildSnapshotDiffPayload(diff)
+				if err != nil {
+					panic(fmt.Errorf("error building payload for compare_snapshots: %v", err))
+				}
+				session.Write(payload)
 			case "select_dashboard":
 				log.Printf("[TRACE] Got event: %v\n", request.Payload.Dashboard)
 				dashboardClientInfo := s.getSession(session)
+				s.mutex.Lock()
 				dashboardClientInfo.Dashboard = &request.Payload.Dashboard.FullName
-				dashboardexecute.ExecuteDashboardNode(ctx, request.Payload.Dashboard.FullName, s.workspace, s.dbClient)
+				// input values are specific to the dashboard, so replace any previous values
+				dashboardClientInfo.DashboardInputs = request.Payload.InputValues
+				s.mutex.Unlock()
+				s.executeSessionDashboard(dashboardClientInfo, false)
+			case "refresh_dashboard":
+				log.Printf("[TRACE] Got refresh event")
+				dashboardClientInfo := s.getSession(session)
+				// ignore if no dashboard is selected
+				if dashboardClientInfo.Dashboard == nil {
+					return
+				}
+				// re-execute the dashboard, bypassing the leaf data cache
+				s.executeSessionDashboard(dashboardClientInfo, true)
+			case "input_changed":
+				log.Printf("[TRACE] Got input changed event: %s\n", request.Payload.ChangedInput)
+				dashboardClientInfo := s.getSession(session)
+				// ignore if no dashboard is selected
+				if dashboardClientInfo.Dashboard == nil {
+					return
+				}
+				s.setDashboardInputs(dashboardClientInfo, request.Payload.InputValues)
+				if err := dashboardexecute.OnInputChanged(dashboardClientInfo.executionContext, dashboardClientInfo.SessionId, request.Payload.ChangedInput, dashboardClientInfo.DashboardInputs); err != nil {
+					log.Printf("[WARN] failed to handle input change for %s: %s", *dashboardClientInfo.Dashboard, err.Error())
+				}
 			}
 		}
 	})
@@ -485,14 +626,124 @@ func (s *Server) getSession(session *melody.Session) *DashboardClientInfo {
 	return dashboardClientInfo
 }
 
+// setDashboardInputs merges the given input values into the session input values
+func (s *Server) setDashboardInputs(dashboardClientInfo *DashboardClientInfo, inputValues map[string]interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if dashboardClientInfo.DashboardInputs == nil {
+		dashboardClientInfo.DashboardInputs = make(map[string]interface{})
+	}
+	for name, value := range inputValues {
+		dashboardClientInfo.DashboardInputs[name] = value
+	}
+}
+
+// compareSnapshots compares the dashboard snapshot of the given scheduled run
+// with either another scheduled run, or the current execution of the session
+func (s *Server) compareSnapshots(dashboardClientInfo *DashboardClientInfo, request ClientRequestPayload) (*dashboardexecute.SnapshotDiff, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("there are no scheduled runs")
+	}
+	previous, err := s.getScheduledSnapshot(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+
+	var current []byte
+	if request.CompareRunId != "" {
+		current, err = s.getScheduledSnapshot(request.CompareRunId)
+	} else {
+		snapshot, ok := dashboardexecute.GetDashboardSnapshot(dashboardClientInfo.SessionId)
+		if !ok {
+			return nil, fmt.Errorf("there is no completed dashboard execution to compare")
+		}
+		current, err = json.Marshal(snapshot)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dashboardexecute.DiffSnapshots(previous, current, request.KeyColumn)
+}
+
+func (s *Server) getScheduledSnapshot(runId string) ([]byte, error) {
+	runResult, err := s.scheduler.GetRunResult(runId)
+	if err != nil {
+		return nil, err
+	}
+	if runResult.Run.TargetType != modconfig.BlockTypeDashboard || len(runResult.Result) == 0 {
+		return nil, fmt.Errorf("scheduled run %s does not have a dashboard snapshot", runId)
+	}
+	return runResult.Result, nil
+}
+
+// writeToSession writes the payload to the session with the given id (if it is still connected)
+func (s *Server) writeToSession(sessionId string, payload []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for session, dashboardClientInfo := range s.dashboardClients {
+		if dashboardClientInfo.SessionId == sessionId {
+			session.Write(payload)
+		}
+	}
+}
+
+// executeSessionDashboard cancels any in-flight execution for the session and executes the selected dashboard
+// if refresh is set, the leaf data cache is bypassed
+func (s *Server) executeSessionDashboard(dashboardClientInfo *DashboardClientInfo, refresh bool) {
+	s.mutex.Lock()
+	if dashboardClientInfo.cancelExecution != nil {
+		dashboardClientInfo.cancelExecution()
+	}
+	executionContext, cancel := context.WithCancel(s.context)
+	dashboardClientInfo.executionContext = executionContext
+	dashboardClientInfo.cancelExecution = cancel
+	dashboardName := typeHelpers.SafeString(dashboardClientInfo.Dashboard)
+	inputs := dashboardClientInfo.DashboardInputs
+	s.mutex.Unlock()
+
+	execute := dashboardexecute.ExecuteDashboardNode
+	if refresh {
+		execute = dashboardexecute.RefreshDashboardNode
+	}
+	if err := execute(executionContext, dashboardClientInfo.SessionId, dashboardName, inputs, s.workspace, s.dbClient); err != nil {
+		log.Printf("[WARN] failed to execute dashboard %s for session %s: %s", dashboardName, dashboardClientInfo.SessionId, err.Error())
+	}
+}
+
+// reexecuteDashboard re-executes the given dashboard for every session which has it selected
+func (s *Server) reexecuteDashboard(dashboardName string) {
+	var watchingClients []*DashboardClientInfo
+	s.mutex.Lock()
+	for _, dashboardClientInfo := range s.dashboardClients {
+		if typeHelpers.SafeString(dashboardClientInfo.Dashboard) == dashboardName {
+			watchingClients = append(watchingClients, dashboardClientInfo)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, dashboardClientInfo := range watchingClients {
+		s.executeSessionDashboard(dashboardClientInfo, false)
+	}
+}
+
 func (s *Server) clearSession(session *melody.Session) {
 	s.mutex.Lock()
+	dashboardClientInfo, ok := s.dashboardClients[session]
 	delete(s.dashboardClients, session)
 	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+	// cancel any in-flight execution for the session
+	if dashboardClientInfo.cancelExecution != nil {
+		dashboardClientInfo.cancelExecution()
+	}
+	dashboardexecute.ClearExecution(dashboardClientInfo.SessionId)
 }
 
 func (s *Server) addSession(session *melody.Session) {
 	s.mutex.Lock()
-	s.dashboardClients[session] = &DashboardClientInfo{}
+	s.dashboardClients[session] = &DashboardClientInfo{SessionId: uuid.NewString()}
 	s.mutex.Unlock()
 }
@@ -1,11 +1,25 @@
 package dashboardserver
 
+import (
+	"encoding/json"
+
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardschedule"
+)
+
 type ClientRequestDashboardPayload struct {
 	FullName string `json:"full_name"`
 }
 
 type ClientRequestPayload struct {
-	Dashboard ClientRequestDashboardPayload `json:"dashboard"`
+	Dashboard    ClientRequestDashboardPayload `json:"dashboard"`
+	InputValues  map[string]interface{}        `json:"input_values"`
+	ChangedInput string                        `json:"changed_input"`
+	RunId        string                        `json:"run_id"`
+	// for compare_snapshots, the run to compare with - if not set, the current execution of the session is used
+	CompareRunId string `json:"compare_run_id"`
+	// for compare_snapshots, the column used to match table rows
+	KeyColumn string `json:"key_column"`
 }
 
 type ClientRequest struct {
@@ -39,3 +53,20 @@ type DashboardMetadataPayload struct {
 	Action   string            `json:"action"`
 	Metadata DashboardMetadata `json:"metadata"`
 }
+
+type ScheduledRunsPayload struct {
+	Action    string                           `json:"action"`
+	Schedules []dashboardschedule.ScheduleInfo `json:"schedules"`
+	Runs      []dashboardschedule.ScheduledRun `json:"runs"`
+}
+
+type ScheduledRunPayload struct {
+	Action string                          `json:"action"`
+	Run    *dashboardschedule.ScheduledRun `json:"run"`
+	Result json.RawMessage                 `json:"result,omitempty"`
+}
+
+type SnapshotDiffPayload struct {
+	Action string                         `json:"action"`
+	Diff   *dashboardexecute.SnapshotDiff `json:"diff"`
+}
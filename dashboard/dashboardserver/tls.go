@@ -0,0 +1,33 @@
+package dashboardserver
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+)
+
+// getTLSCertificate returns the certificate and key files used to serve the dashboard over TLS
+// TLS is enabled if the 'tls' option is set, or if a certificate is given
+// - if no certificate is given, the self signed certificate generated for the service is used
+func getTLSCertificate() (certFile string, keyFile string, enabled bool, err error) {
+	certFile = viper.GetString(constants.ArgDashboardServerTLSCertificate)
+	keyFile = viper.GetString(constants.ArgDashboardServerTLSKey)
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return "", "", false, fmt.Errorf("both 'tls_certificate' and 'tls_key' must be set to use a custom dashboard certificate")
+		}
+		return certFile, keyFile, true, nil
+	}
+	if !viper.GetBool(constants.ArgDashboardServerTLS) {
+		return "", "", false, nil
+	}
+
+	certFile, keyFile, err = db_local.EnsureServerCertificate()
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to create self signed certificate for the dashboard server: %s", err.Error())
+	}
+	return certFile, keyFile, true, nil
+}
@@ -0,0 +1,75 @@
+package dashboardserver
+
+import (
+	"github.com/turbot/steampipe/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/dashboard/dashboardschedule"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/webhooks"
+)
+
+// handleWebhookEvent sends dashboard completion and error events to the configured webhooks
+func (s *Server) handleWebhookEvent(event dashboardevents.DashboardEvent) {
+	var webhookEvent *webhooks.Event
+	switch e := event.(type) {
+	case *dashboardevents.ExecutionComplete:
+		webhookEvent = newDashboardWebhookEvent(e.Dashboard)
+	case *dashboardevents.LeafNodeError:
+		webhookEvent = webhooks.NewEvent(webhooks.EventLeafError, e.Node.GetName())
+		webhookEvent.Status = string(e.Node.GetRunStatus())
+		webhookEvent.Error = runError(e.Node)
+	default:
+		return
+	}
+	// do not block the execution
+	go s.notifier.Notify(s.context, webhookEvent)
+}
+
+// handleScheduledRunWebhookEvent sends the completion event of a scheduled run to the configured webhooks
+// (scheduled runs do not publish execution complete events, so are not handled by handleWebhookEvent)
+func (s *Server) handleScheduledRunWebhookEvent(run *dashboardschedule.ScheduledRun) {
+	var webhookEvent *webhooks.Event
+	switch run.TargetType {
+	case modconfig.BlockTypeDashboard:
+		webhookEvent = webhooks.NewEvent(webhooks.EventDashboardComplete, run.Target)
+		if run.Status == dashboardinterfaces.DashboardRunError {
+			webhookEvent.Type = webhooks.EventDashboardError
+		}
+		webhookEvent.Status = string(run.Status)
+		webhookEvent.Error = run.Error
+	case modconfig.BlockTypeBenchmark:
+		var summary map[string]int
+		if run.Summary != nil {
+			summary = run.Summary.Counts()
+		}
+		webhookEvent = webhooks.NewCheckEvent(run.Target, string(run.Status), run.Error, summary)
+	default:
+		return
+	}
+	go s.notifier.Notify(s.context, webhookEvent)
+}
+
+func newDashboardWebhookEvent(dashboard dashboardinterfaces.DashboardNodeRun) *webhooks.Event {
+	webhookEvent := webhooks.NewEvent(webhooks.EventDashboardComplete, dashboard.GetName())
+	webhookEvent.Status = string(dashboard.GetRunStatus())
+	if dashboard.GetRunStatus() == dashboardinterfaces.DashboardRunError {
+		webhookEvent.Type = webhooks.EventDashboardError
+		webhookEvent.Error = runError(dashboard)
+	}
+	return webhookEvent
+}
+
+func runError(run dashboardinterfaces.DashboardNodeRun) string {
+	switch r := run.(type) {
+	case *dashboardexecute.DashboardRun:
+		return r.ErrorString
+	case *dashboardexecute.DashboardContainerRun:
+		return r.ErrorString
+	case *dashboardexecute.LeafRun:
+		return r.ErrorString
+	case *dashboardexecute.CheckRun:
+		return r.ErrorString
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/utils"
 )
@@ -27,9 +28,9 @@ func (r *InitResult) HasMessages() bool {
 }
 
 func (r *InitResult) DisplayMessages() {
-	// do not display message in json or csv output mode
-	output := viper.Get(constants.ArgOutput)
-	if output == constants.OutputFormatJSON || output == constants.OutputFormatCSV {
+	// do not display message in json, csv, document or binary output modes
+	output := viper.GetString(constants.ArgOutput)
+	if helpers.StringSliceContains([]string{constants.OutputFormatJSON, constants.OutputFormatCSV, constants.OutputFormatMarkdown, constants.OutputFormatHTML, constants.OutputFormatSQL, constants.OutputFormatParquet, constants.OutputFormatArrow}, output) {
 		return
 	}
 	for _, w := range r.Warnings {
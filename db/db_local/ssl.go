@@ -80,6 +80,15 @@ func ValidateServerCertificate() bool {
 	return (serverCertificate.Issuer.CommonName == CertIssuer) && isCerticateExpiring(serverCertificate)
 }
 
+// EnsureServerCertificate ensures the self signed service certificate and key exist,
+// and returns their locations (these are also used to serve the dashboard over TLS)
+func EnsureServerCertificate() (certFile string, keyFile string, err error) {
+	if err := ensureSelfSignedCertificate(); err != nil {
+		return "", "", err
+	}
+	return getServerCertLocation(), getServerCertKeyLocation(), nil
+}
+
 // if certificate or private key files do not exist, generate them
 func ensureSelfSignedCertificate() (err error) {
 	if serverCertificateAndKeyExist() && rootCertificateAndKeyExists() {
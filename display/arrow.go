@@ -0,0 +1,145 @@
+package display
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+)
+
+// the arrow field metadata key used to record the database type of each column
+const arrowDatabaseTypeMetadataKey = "steampipe:database_type"
+
+func displayArrow(ctx context.Context, result *queryresult.Result) {
+	if err := writeArrow(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+// writeArrow writes the result in the arrow IPC streaming format
+// rows are written in record batches of ColumnarBatchSize, so the full result set is never held in memory
+func writeArrow(out io.Writer, result *queryresult.Result) error {
+	columnTypes := make([]columnarType, len(result.ColTypes))
+	for idx, colType := range result.ColTypes {
+		columnTypes[idx] = getColumnarType(colType)
+	}
+	schema := arrowSchema(result.ColTypes, columnTypes)
+
+	mem := memory.NewGoAllocator()
+	builder := array.NewRecordBuilder(mem, schema)
+	defer builder.Release()
+	writer := ipc.NewWriter(out, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+
+	rowsInBatch := 0
+	writeBatch := func() error {
+		record := builder.NewRecord()
+		defer record.Release()
+		rowsInBatch = 0
+		return writer.Write(record)
+	}
+
+	// once a write has failed, ignore subsequent rows (they must still be read to complete the query)
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		if writeErr = appendArrowRow(builder, row, result.ColTypes, columnTypes); writeErr != nil {
+			return
+		}
+		rowsInBatch++
+		if rowsInBatch == constants.ColumnarBatchSize {
+			writeErr = writeBatch()
+		}
+	}
+
+	// call this function for each row
+	err := iterateResults(result, rowFunc)
+	if writeErr == nil && err == nil && rowsInBatch > 0 {
+		writeErr = writeBatch()
+	}
+	// always close the writer, so that the stream is terminated
+	if closeErr := writer.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("unable to write arrow output: %v", writeErr)
+	}
+	return nil
+}
+
+func arrowSchema(colTypes []*sql.ColumnType, columnTypes []columnarType) *arrow.Schema {
+	names := columnarFieldNames(colTypes)
+	fields := make([]arrow.Field, len(colTypes))
+	for idx, colType := range colTypes {
+		fields[idx] = arrow.Field{
+			Name:     names[idx],
+			Type:     arrowDataType(columnTypes[idx]),
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{arrowDatabaseTypeMetadataKey}, []string{colType.DatabaseTypeName()}),
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func arrowDataType(columnType columnarType) arrow.DataType {
+	switch columnType {
+	case columnarBool:
+		return arrow.FixedWidthTypes.Boolean
+	case columnarInt64:
+		return arrow.PrimitiveTypes.Int64
+	case columnarFloat64:
+		return arrow.PrimitiveTypes.Float64
+	case columnarTimestamp:
+		return arrow.FixedWidthTypes.Timestamp_us
+	case columnarDate:
+		return arrow.FixedWidthTypes.Date32
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func appendArrowRow(builder *array.RecordBuilder, row []interface{}, colTypes []*sql.ColumnType, columnTypes []columnarType) error {
+	for idx, val := range row {
+		fieldBuilder := builder.Field(idx)
+		if val == nil {
+			fieldBuilder.AppendNull()
+			continue
+		}
+		value, err := columnarValue(val, colTypes[idx], columnTypes[idx])
+		if err != nil {
+			return err
+		}
+		switch b := fieldBuilder.(type) {
+		case *array.BooleanBuilder:
+			b.Append(value.(bool))
+		case *array.Int64Builder:
+			b.Append(value.(int64))
+		case *array.Float64Builder:
+			b.Append(value.(float64))
+		case *array.TimestampBuilder:
+			b.Append(arrow.Timestamp(value.(time.Time).UnixMicro()))
+		case *array.Date32Builder:
+			b.Append(arrow.Date32(value.(int32)))
+		case *array.StringBuilder:
+			b.Append(value.(string))
+		default:
+			return fmt.Errorf("unsupported arrow builder %T", fieldBuilder)
+		}
+	}
+	return nil
+}
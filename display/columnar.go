@@ -0,0 +1,136 @@
+package display
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	typeHelpers "github.com/turbot/go-kit/types"
+)
+
+// columnarType is the type used to represent a query column in the columnar (parquet and arrow) output formats
+type columnarType int
+
+const (
+	columnarString columnarType = iota
+	columnarJSON
+	columnarBool
+	columnarInt64
+	columnarFloat64
+	columnarTimestamp
+	columnarDate
+)
+
+// getColumnarType maps the database type of a column to a columnar type
+// types with no columnar equivalent (e.g. inet, numeric, arrays) are represented as strings
+func getColumnarType(colType *sql.ColumnType) columnarType {
+	switch colType.DatabaseTypeName() {
+	case "BOOL":
+		return columnarBool
+	case "INT2", "INT4", "INT8":
+		return columnarInt64
+	case "FLOAT4", "FLOAT8":
+		return columnarFloat64
+	case "TIMESTAMP", "TIMESTAMPTZ":
+		return columnarTimestamp
+	case "DATE":
+		return columnarDate
+	case "JSON", "JSONB":
+		return columnarJSON
+	default:
+		return columnarString
+	}
+}
+
+// columnarFieldNames returns the names to use for the columnar fields
+// columnar formats require unique field names, so duplicate column names
+// (compared case insensitively) are suffixed with an index
+func columnarFieldNames(colTypes []*sql.ColumnType) []string {
+	names := make([]string, len(colTypes))
+	used := make(map[string]bool)
+	for i, name := range ColumnNames(colTypes) {
+		uniqueName := name
+		for suffix := 1; used[strings.ToLower(uniqueName)]; suffix++ {
+			uniqueName = fmt.Sprintf("%s_%d", name, suffix)
+		}
+		used[strings.ToLower(uniqueName)] = true
+		names[i] = uniqueName
+	}
+	return names
+}
+
+// convert a (non-null) column value into the go type used to write the given columnar type:
+// string, bool, int64, float64, time.Time (for timestamps) or int32 days since epoch (for dates)
+func columnarValue(val interface{}, colType *sql.ColumnType, columnType columnarType) (interface{}, error) {
+	switch columnType {
+	case columnarJSON:
+		bytes, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		return string(bytes), nil
+	case columnarBool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+		return strconv.ParseBool(typeHelpers.ToString(val))
+	case columnarInt64:
+		return columnarInt64Value(val)
+	case columnarFloat64:
+		return columnarFloat64Value(val)
+	case columnarTimestamp, columnarDate:
+		t, ok := val.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("column '%s': cannot convert %T to a timestamp", colType.Name(), val)
+		}
+		if columnType == columnarDate {
+			// dates are stored as the number of days since the epoch
+			date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			return int32(date.Unix() / (24 * 60 * 60)), nil
+		}
+		return t.UTC(), nil
+	default:
+		return ColumnValueAsString(val, colType)
+	}
+}
+
+func columnarInt64Value(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64", v)
+		}
+		return int64(v), nil
+	}
+	return strconv.ParseInt(typeHelpers.ToString(val), 10, 64)
+}
+
+func columnarFloat64Value(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return strconv.ParseFloat(typeHelpers.ToString(val), 64)
+}
@@ -0,0 +1,230 @@
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+)
+
+const (
+	rowAdded   = "added"
+	rowRemoved = "removed"
+	rowChanged = "changed"
+)
+
+// rowDiff is a row which has been added, removed or changed since the previous result
+type rowDiff struct {
+	change string
+	// for removed rows, the previous row - otherwise the current row
+	row map[string]interface{}
+	// for changed rows, the previous row
+	previous map[string]interface{}
+}
+
+// displayDiff displays the rows of the result which have been added, removed or changed
+// since the previous result, which is read from a file written by the JSON output
+func displayDiff(ctx context.Context, result *queryresult.Result, previousResultFile string) {
+	current, err := readJSONRecords(result)
+	if err != nil {
+		utils.ShowError(ctx, err)
+		return
+	}
+	previous, err := readPreviousResult(previousResultFile)
+	if err != nil {
+		utils.ShowError(ctx, err)
+		return
+	}
+	// round trip the current rows through JSON so values are compared like for like with the previous result
+	if current, err = normaliseJSONRecords(current); err != nil {
+		utils.ShowError(ctx, err)
+		return
+	}
+
+	columns := diffColumns(ColumnNames(result.ColTypes), previous)
+	keyColumns := cmdconfig.Viper().GetStringSlice(constants.ArgDiffKey)
+	diffs, err := diffRows(previous, current, columns, keyColumns)
+	if err != nil {
+		utils.ShowError(ctx, err)
+		return
+	}
+
+	if len(diffs) == 0 {
+		fmt.Println("No differences")
+		return
+	}
+
+	rows := make([][]string, len(diffs))
+	counts := make(map[string]int)
+	for i, diff := range diffs {
+		rows[i] = diffRowAsStrings(diff, columns)
+		counts[diff.change]++
+	}
+	ShowWrappedTable(append([]string{"change"}, columns...), rows, false)
+	fmt.Printf("\n%d added, %d removed, %d changed\n", counts[rowAdded], counts[rowRemoved], counts[rowChanged])
+}
+
+func readPreviousResult(previousResultFile string) ([]map[string]interface{}, error) {
+	data, err := os.ReadFile(previousResultFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous result: %v", err)
+	}
+	var previous []map[string]interface{}
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to read previous result '%s' - expected the JSON output of a query: %v", previousResultFile, err)
+	}
+	return previous, nil
+}
+
+func normaliseJSONRecords(records []map[string]interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	var res []map[string]interface{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// the columns to display - the current columns, followed by any columns which only exist in the previous result
+func diffColumns(currentColumns []string, previous []map[string]interface{}) []string {
+	columnLookup := make(map[string]bool)
+	for _, column := range currentColumns {
+		columnLookup[column] = true
+	}
+	var previousColumns []string
+	for _, row := range previous {
+		for column := range row {
+			if !columnLookup[column] {
+				columnLookup[column] = true
+				previousColumns = append(previousColumns, column)
+			}
+		}
+	}
+	sort.Strings(previousColumns)
+	return append(currentColumns, previousColumns...)
+}
+
+// diffRows compares the previous and current rows, matching rows using the values of the key columns
+// if no key columns are given, the full row is used as the key (so rows can only be added or removed)
+// added and changed rows are returned in the order of the current result, followed by the removed rows
+func diffRows(previous, current []map[string]interface{}, columns, keyColumns []string) ([]*rowDiff, error) {
+	for _, keyColumn := range keyColumns {
+		if !helpers.StringSliceContains(columns, keyColumn) {
+			return nil, fmt.Errorf("diff key column '%s' is not a column of the result", keyColumn)
+		}
+	}
+	// duplicate keys are only an error if the key columns were specified
+	// - if the full row is used, identical rows are matched in order
+	allowDuplicates := len(keyColumns) == 0
+	if allowDuplicates {
+		keyColumns = columns
+	}
+
+	// build a map of the indices of the previous rows, keyed by the key column values
+	previousIndices, err := rowIndicesByKey(previous, keyColumns, allowDuplicates, "previous")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rowIndicesByKey(current, keyColumns, allowDuplicates, "current"); err != nil {
+		return nil, err
+	}
+
+	var diffs []*rowDiff
+	matched := make([]bool, len(previous))
+	for _, row := range current {
+		key, _ := rowKey(row, keyColumns)
+		indices := previousIndices[key]
+		if len(indices) == 0 {
+			diffs = append(diffs, &rowDiff{change: rowAdded, row: row})
+			continue
+		}
+		previousRow := previous[indices[0]]
+		matched[indices[0]] = true
+		previousIndices[key] = indices[1:]
+
+		if changedColumns(previousRow, row, columns) > 0 {
+			diffs = append(diffs, &rowDiff{change: rowChanged, row: row, previous: previousRow})
+		}
+	}
+	for i, row := range previous {
+		if !matched[i] {
+			diffs = append(diffs, &rowDiff{change: rowRemoved, row: row})
+		}
+	}
+	return diffs, nil
+}
+
+func rowIndicesByKey(rows []map[string]interface{}, keyColumns []string, allowDuplicates bool, source string) (map[string][]int, error) {
+	res := make(map[string][]int, len(rows))
+	for i, row := range rows {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := res[key]; ok && !allowDuplicates {
+			return nil, fmt.Errorf("the diff key (%s) does not uniquely identify the rows of the %s result - duplicate key %s", strings.Join(keyColumns, ", "), source, key)
+		}
+		res[key] = append(res[key], i)
+	}
+	return res, nil
+}
+
+func rowKey(row map[string]interface{}, keyColumns []string) (string, error) {
+	values := make([]interface{}, len(keyColumns))
+	for i, column := range keyColumns {
+		values[i] = row[column]
+	}
+	key, err := json.Marshal(values)
+	return string(key), err
+}
+
+func changedColumns(previous, current map[string]interface{}, columns []string) int {
+	count := 0
+	for _, column := range columns {
+		if diffValueAsString(previous[column]) != diffValueAsString(current[column]) {
+			count++
+		}
+	}
+	return count
+}
+
+// convert a row diff into the strings to display - changed values are shown as '<previous> -> <current>'
+func diffRowAsStrings(diff *rowDiff, columns []string) []string {
+	res := make([]string, len(columns)+1)
+	res[0] = diff.change
+	for i, column := range columns {
+		value := diffValueAsString(diff.row[column])
+		if diff.change == rowChanged {
+			if previousValue := diffValueAsString(diff.previous[column]); previousValue != value {
+				value = fmt.Sprintf("%s -> %s", previousValue, value)
+			}
+		}
+		res[i+1] = value
+	}
+	return res
+}
+
+func diffValueAsString(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return constants.NullString
+	case string:
+		return v
+	}
+	bytes, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(bytes)
+}
@@ -0,0 +1,68 @@
+package display
+
+import (
+	"reflect"
+	"testing"
+)
+
+type diffRowsTest struct {
+	previous   []map[string]interface{}
+	current    []map[string]interface{}
+	keyColumns []string
+	expected   []string
+}
+
+var testCasesDiffRows = map[string]diffRowsTest{
+	"no changes": {
+		previous: []map[string]interface{}{{"id": "a", "size": 1.0}},
+		current:  []map[string]interface{}{{"id": "a", "size": 1.0}},
+		expected: nil,
+	},
+	"keyed": {
+		previous:   []map[string]interface{}{{"id": "a", "size": 1.0}, {"id": "b", "size": 2.0}},
+		current:    []map[string]interface{}{{"id": "a", "size": 3.0}, {"id": "c", "size": 2.0}},
+		keyColumns: []string{"id"},
+		expected:   []string{"changed a", "added c", "removed b"},
+	},
+	"full row key": {
+		previous: []map[string]interface{}{{"id": "a", "size": 1.0}},
+		current:  []map[string]interface{}{{"id": "a", "size": 3.0}},
+		expected: []string{"added a", "removed a"},
+	},
+	"full row key duplicates": {
+		previous: []map[string]interface{}{{"id": "a", "size": 1.0}, {"id": "a", "size": 1.0}},
+		current:  []map[string]interface{}{{"id": "a", "size": 1.0}},
+		expected: []string{"removed a"},
+	},
+	"duplicate key": {
+		previous:   []map[string]interface{}{{"id": "a", "size": 1.0}, {"id": "a", "size": 2.0}},
+		current:    []map[string]interface{}{{"id": "a", "size": 1.0}},
+		keyColumns: []string{"id"},
+		expected:   []string{"ERROR"},
+	},
+	"invalid key": {
+		previous:   []map[string]interface{}{{"id": "a", "size": 1.0}},
+		current:    []map[string]interface{}{{"id": "a", "size": 1.0}},
+		keyColumns: []string{"name"},
+		expected:   []string{"ERROR"},
+	},
+}
+
+func TestDiffRows(t *testing.T) {
+	for name, test := range testCasesDiffRows {
+		diffs, err := diffRows(test.previous, test.current, []string{"id", "size"}, test.keyColumns)
+		if err != nil {
+			if !reflect.DeepEqual(test.expected, []string{"ERROR"}) {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			}
+			continue
+		}
+		var res []string
+		for _, diff := range diffs {
+			res = append(res, diff.change+" "+diff.row["id"].(string))
+		}
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected:\n%v\n\ngot:\n%v", name, test.expected, res)
+		}
+	}
+}
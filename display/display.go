@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"unicode/utf8"
@@ -22,6 +23,12 @@ import (
 
 // ShowOutput :: displays the output using the proper formatter as applicable
 func ShowOutput(ctx context.Context, result *queryresult.Result) {
+	// if a previous result has been provided, display the differences from it
+	if previousResultFile := cmdconfig.Viper().GetString(constants.ArgDiffAgainst); previousResultFile != "" {
+		displayDiff(ctx, result, previousResultFile)
+		return
+	}
+
 	output := cmdconfig.Viper().GetString(constants.ArgOutput)
 	if output == constants.OutputFormatJSON {
 		displayJSON(ctx, result)
@@ -29,6 +36,16 @@ func ShowOutput(ctx context.Context, result *queryresult.Result) {
 		displayCSV(ctx, result)
 	} else if output == constants.OutputFormatLine {
 		displayLine(ctx, result)
+	} else if output == constants.OutputFormatMarkdown {
+		displayMarkdown(ctx, result)
+	} else if output == constants.OutputFormatHTML {
+		displayHTML(ctx, result)
+	} else if output == constants.OutputFormatSQL {
+		displaySQL(ctx, result)
+	} else if output == constants.OutputFormatParquet {
+		displayParquet(ctx, result)
+	} else if output == constants.OutputFormatArrow {
+		displayArrow(ctx, result)
 	} else {
 		// default
 		displayTable(ctx, result)
@@ -175,7 +192,31 @@ func getTerminalColumnsRequiredForString(str string) int {
 }
 
 func displayJSON(ctx context.Context, result *queryresult.Result) {
-	var jsonOutput []map[string]interface{}
+	if err := writeJSON(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+		return
+	}
+	fmt.Println()
+}
+
+func writeJSON(out io.Writer, result *queryresult.Result) error {
+	jsonOutput, err := readJSONRecords(result)
+	if err != nil {
+		return err
+	}
+	// display the JSON
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", " ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(jsonOutput); err != nil {
+		return fmt.Errorf("error displaying result as JSON: %v", err)
+	}
+	return nil
+}
+
+// readJSONRecords reads the result rows into a list of records, in the form written by the JSON output
+func readJSONRecords(result *queryresult.Result) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
 
 	// define function to add each row to the JSON output
 	rowFunc := func(row []interface{}, result *queryresult.Result) {
@@ -184,27 +225,24 @@ func displayJSON(ctx context.Context, result *queryresult.Result) {
 			value, _ := ParseJSONOutputColumnValue(row[idx], colType)
 			record[colType.Name()] = value
 		}
-		jsonOutput = append(jsonOutput, record)
+		records = append(records, record)
 	}
 
 	// call this function for each row
 	if err := iterateResults(result, rowFunc); err != nil {
-		utils.ShowError(ctx, err)
-		return
-	}
-	// display the JSON
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", " ")
-	encoder.SetEscapeHTML(false)
-	if err := encoder.Encode(jsonOutput); err != nil {
-		fmt.Print("Error displaying result as JSON", err)
-		return
+		return nil, err
 	}
-	fmt.Println()
+	return records, nil
 }
 
 func displayCSV(ctx context.Context, result *queryresult.Result) {
-	csvWriter := csv.NewWriter(os.Stdout)
+	if err := writeCSV(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+func writeCSV(out io.Writer, result *queryresult.Result) error {
+	csvWriter := csv.NewWriter(out)
 	csvWriter.Comma = []rune(cmdconfig.Viper().GetString(constants.ArgSeparator))[0]
 
 	if cmdconfig.Viper().GetBool(constants.ArgHeader) {
@@ -220,14 +258,14 @@ func displayCSV(ctx context.Context, result *queryresult.Result) {
 
 	// call this function for each row
 	if err := iterateResults(result, rowFunc); err != nil {
-		utils.ShowError(ctx, err)
-		return
+		return err
 	}
 
 	csvWriter.Flush()
 	if csvWriter.Error() != nil {
-		utils.ShowErrorWithMessage(ctx, csvWriter.Error(), "unable to print csv")
+		return fmt.Errorf("unable to print csv: %v", csvWriter.Error())
 	}
+	return nil
 }
 
 func displayTable(ctx context.Context, result *queryresult.Result) {
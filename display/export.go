@@ -0,0 +1,93 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+)
+
+// exportFormatExtensions maps each supported query export format to the extension of its output file
+var exportFormatExtensions = map[string]string{
+	constants.OutputFormatCSV:      ".csv",
+	constants.OutputFormatJSON:     ".json",
+	constants.OutputFormatMarkdown: ".md",
+	constants.OutputFormatHTML:     ".html",
+	constants.OutputFormatSQL:      ".sql",
+	constants.OutputFormatParquet:  ".parquet",
+	constants.OutputFormatArrow:    ".arrow",
+}
+
+// ResolveExportFormat parses an export argument, returning the export format and the target file name (if any)
+// the argument may either be the name of a format, or a file name whose extension identifies the format
+func ResolveExportFormat(export string) (format string, targetFileName string, err error) {
+	// is this the name of a format
+	if _, ok := exportFormatExtensions[export]; ok {
+		return export, "", nil
+	}
+
+	// so this must be a file name - find the format from the extension
+	extension := strings.ToLower(filepath.Ext(export))
+	for format, formatExtension := range exportFormatExtensions {
+		if extension == formatExtension {
+			return format, export, nil
+		}
+	}
+	return "", "", fmt.Errorf("cannot determine the export format for '%s' - supported formats are %s", export, strings.Join(ExportFormats(), ", "))
+}
+
+// ExportFormats returns the names of the supported query export formats
+func ExportFormats() []string {
+	return []string{
+		constants.OutputFormatCSV,
+		constants.OutputFormatJSON,
+		constants.OutputFormatMarkdown,
+		constants.OutputFormatHTML,
+		constants.OutputFormatSQL,
+		constants.OutputFormatParquet,
+		constants.OutputFormatArrow,
+	}
+}
+
+// ExportFileExtension returns the file extension used for the given export format
+func ExportFileExtension(format string) string {
+	return exportFormatExtensions[format]
+}
+
+// ExportResult writes the result to a file, in the given export format
+func ExportResult(result *queryresult.Result, format string, fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		// we must still read the result to complete the query
+		iterateResults(result, func([]interface{}, *queryresult.Result) {})
+		return err
+	}
+	defer file.Close()
+
+	return writeResult(file, result, format)
+}
+
+func writeResult(out io.Writer, result *queryresult.Result, format string) error {
+	switch format {
+	case constants.OutputFormatCSV:
+		return writeCSV(out, result)
+	case constants.OutputFormatJSON:
+		return writeJSON(out, result)
+	case constants.OutputFormatMarkdown:
+		return writeMarkdown(out, result)
+	case constants.OutputFormatHTML:
+		return writeHTML(out, result)
+	case constants.OutputFormatSQL:
+		return writeSQL(out, result)
+	case constants.OutputFormatParquet:
+		return writeParquet(out, result)
+	case constants.OutputFormatArrow:
+		return writeArrow(out, result)
+	}
+	iterateResults(result, func([]interface{}, *queryresult.Result) {})
+	return fmt.Errorf("unsupported export format '%s'", format)
+}
@@ -0,0 +1,80 @@
+package display
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+)
+
+const htmlDocumentHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Steampipe query result</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; font-size: 14px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; white-space: pre-wrap; }
+th { background-color: #f3f3f3; }
+</style>
+</head>
+<body>
+<table>
+`
+
+const htmlDocumentFooter = `</tbody>
+</table>
+</body>
+</html>
+`
+
+func displayHTML(ctx context.Context, result *queryresult.Result) {
+	if err := writeHTML(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+// writeHTML writes the result as a standalone html document containing a single table
+func writeHTML(out io.Writer, result *queryresult.Result) error {
+	writer := bufio.NewWriter(out)
+	writer.WriteString(htmlDocumentHeader)
+
+	if cmdconfig.Viper().GetBool(constants.ArgHeader) {
+		writer.WriteString("<thead>\n")
+		writeHTMLRow(writer, "th", ColumnNames(result.ColTypes))
+		writer.WriteString("</thead>\n")
+	}
+	writer.WriteString("<tbody>\n")
+
+	// define function to write each html row
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		rowAsString, _ := ColumnValuesAsString(row, result.ColTypes)
+		writeHTMLRow(writer, "td", rowAsString)
+	}
+
+	// call this function for each row
+	if err := iterateResults(result, rowFunc); err != nil {
+		return err
+	}
+
+	writer.WriteString(htmlDocumentFooter)
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("unable to write html output: %v", err)
+	}
+	return nil
+}
+
+func writeHTMLRow(writer *bufio.Writer, cellTag string, cells []string) {
+	writer.WriteString("<tr>")
+	for _, cell := range cells {
+		fmt.Fprintf(writer, "<%s>%s</%s>", cellTag, html.EscapeString(cell), cellTag)
+	}
+	writer.WriteString("</tr>\n")
+}
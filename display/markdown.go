@@ -0,0 +1,64 @@
+package display
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+)
+
+// characters which would break the layout of a markdown table cell
+var markdownCellReplacer = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+func displayMarkdown(ctx context.Context, result *queryresult.Result) {
+	if err := writeMarkdown(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+// writeMarkdown writes the result as a GitHub flavoured markdown table
+// NOTE: markdown tables must have a header row, so the header is always written
+func writeMarkdown(out io.Writer, result *queryresult.Result) error {
+	writer := bufio.NewWriter(out)
+
+	colNames := ColumnNames(result.ColTypes)
+	separators := make([]string, len(colNames))
+	for idx, colName := range colNames {
+		colNames[idx] = markdownCellReplacer.Replace(colName)
+		separators[idx] = "---"
+	}
+	writeMarkdownRow(writer, colNames)
+	writeMarkdownRow(writer, separators)
+
+	// define function to write each markdown row
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		rowAsString, _ := ColumnValuesAsString(row, result.ColTypes)
+		for idx, val := range rowAsString {
+			rowAsString[idx] = markdownCellReplacer.Replace(val)
+		}
+		writeMarkdownRow(writer, rowAsString)
+	}
+
+	// call this function for each row
+	if err := iterateResults(result, rowFunc); err != nil {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("unable to write markdown output: %v", err)
+	}
+	return nil
+}
+
+func writeMarkdownRow(writer *bufio.Writer, cells []string) {
+	fmt.Fprintf(writer, "| %s |\n", strings.Join(cells, " | "))
+}
@@ -0,0 +1,116 @@
+package display
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+func displayParquet(ctx context.Context, result *queryresult.Result) {
+	if err := writeParquet(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+// writeParquet writes the result as a parquet file
+// row groups are flushed once they reach ParquetRowGroupSize, so the full result set is never held in memory
+func writeParquet(out io.Writer, result *queryresult.Result) error {
+	columnTypes := make([]columnarType, len(result.ColTypes))
+	for idx, colType := range result.ColTypes {
+		columnTypes[idx] = getColumnarType(colType)
+	}
+
+	parquetWriter, err := writer.NewCSVWriterFromWriter(parquetSchema(result.ColTypes, columnTypes), out, 1)
+	if err != nil {
+		// we must still read the result to complete the query
+		iterateResults(result, func([]interface{}, *queryresult.Result) {})
+		return fmt.Errorf("unable to write parquet output: %v", err)
+	}
+	parquetWriter.RowGroupSize = constants.ParquetRowGroupSize
+
+	// once a write has failed, ignore subsequent rows (they must still be read to complete the query)
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		var record []interface{}
+		if record, writeErr = parquetRecord(row, result.ColTypes, columnTypes); writeErr != nil {
+			return
+		}
+		writeErr = parquetWriter.Write(record)
+	}
+
+	// call this function for each row
+	if err := iterateResults(result, rowFunc); err != nil {
+		return err
+	}
+	if writeErr == nil {
+		// write the final row group and the file footer
+		writeErr = parquetWriter.WriteStop()
+	}
+	if writeErr != nil {
+		return fmt.Errorf("unable to write parquet output: %v", writeErr)
+	}
+	return nil
+}
+
+// build the parquet schema definition, in the metadata format understood by the parquet CSV writer
+func parquetSchema(colTypes []*sql.ColumnType, columnTypes []columnarType) []string {
+	// ',' and '=' are separators in the schema definition so cannot be used in a field name
+	nameReplacer := strings.NewReplacer(",", "_", "=", "_")
+
+	names := columnarFieldNames(colTypes)
+	schema := make([]string, len(colTypes))
+	for idx := range colTypes {
+		schema[idx] = fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", nameReplacer.Replace(names[idx]), parquetType(columnTypes[idx]))
+	}
+	return schema
+}
+
+func parquetType(columnType columnarType) string {
+	switch columnType {
+	case columnarJSON:
+		return "type=BYTE_ARRAY, convertedtype=JSON"
+	case columnarBool:
+		return "type=BOOLEAN"
+	case columnarInt64:
+		return "type=INT64"
+	case columnarFloat64:
+		return "type=DOUBLE"
+	case columnarTimestamp:
+		return "type=INT64, convertedtype=TIMESTAMP_MICROS"
+	case columnarDate:
+		return "type=INT32, convertedtype=DATE"
+	default:
+		return "type=BYTE_ARRAY, convertedtype=UTF8"
+	}
+}
+
+func parquetRecord(row []interface{}, colTypes []*sql.ColumnType, columnTypes []columnarType) ([]interface{}, error) {
+	record := make([]interface{}, len(row))
+	for idx, val := range row {
+		if val == nil {
+			continue
+		}
+		value, err := columnarValue(val, colTypes[idx], columnTypes[idx])
+		if err != nil {
+			return nil, err
+		}
+		// timestamps are stored as microseconds since the epoch
+		if t, ok := value.(time.Time); ok {
+			value = t.UnixMicro()
+		}
+		record[idx] = value
+	}
+	return record, nil
+}
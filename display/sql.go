@@ -0,0 +1,138 @@
+package display
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	typeHelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/utils"
+)
+
+func displaySQL(ctx context.Context, result *queryresult.Result) {
+	if err := writeSQL(os.Stdout, result); err != nil {
+		utils.ShowError(ctx, err)
+	}
+}
+
+// writeSQL writes the result as postgres INSERT statements for the table given by the sql-table arg
+func writeSQL(out io.Writer, result *queryresult.Result) error {
+	tableName := cmdconfig.Viper().GetString(constants.ArgSQLTable)
+	if tableName == "" {
+		// we must still read the result to complete the query
+		iterateResults(result, func([]interface{}, *queryresult.Result) {})
+		return fmt.Errorf("a target table must be specified for sql output, using --%s", constants.ArgSQLTable)
+	}
+
+	colNames := ColumnNames(result.ColTypes)
+	for idx, colName := range colNames {
+		colNames[idx] = sqlIdentifier(colName)
+	}
+	insertPrefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", sqlTableName(tableName), strings.Join(colNames, ", "))
+
+	writer := bufio.NewWriter(out)
+	// once a value has failed to convert, ignore subsequent rows (they must still be read to complete the query)
+	var valueErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if valueErr != nil {
+			return
+		}
+		values := make([]string, len(row))
+		for idx, val := range row {
+			if values[idx], valueErr = sqlLiteral(val, result.ColTypes[idx]); valueErr != nil {
+				return
+			}
+		}
+		fmt.Fprintf(writer, "%s%s);\n", insertPrefix, strings.Join(values, ", "))
+	}
+
+	// call this function for each row
+	if err := iterateResults(result, rowFunc); err != nil {
+		return err
+	}
+	if valueErr != nil {
+		return fmt.Errorf("unable to write sql output: %v", valueErr)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("unable to write sql output: %v", err)
+	}
+	return nil
+}
+
+// sqlTableName quotes each part of a (possibly schema qualified) table name
+// a name which already contains quotes is assumed to be escaped, and is used as is
+func sqlTableName(name string) string {
+	if strings.Contains(name, `"`) {
+		return name
+	}
+	parts := strings.Split(name, ".")
+	for idx, part := range parts {
+		parts[idx] = sqlIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func sqlIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+func sqlString(str string) string {
+	return fmt.Sprintf(`'%s'`, strings.ReplaceAll(str, `'`, `''`))
+}
+
+// sqlLiteral converts a column value into a postgres literal
+func sqlLiteral(val interface{}, colType *sql.ColumnType) (string, error) {
+	if val == nil {
+		return "NULL", nil
+	}
+
+	switch colType.DatabaseTypeName() {
+	case "BOOL":
+		if b, ok := val.(bool); ok {
+			return strings.ToUpper(strconv.FormatBool(b)), nil
+		}
+	case "INT2", "INT4", "INT8", "NUMERIC":
+		return typeHelpers.ToString(val), nil
+	case "FLOAT4", "FLOAT8":
+		if f, ok := val.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			// NaN and Infinity must be quoted
+			return sqlString(typeHelpers.ToString(val)), nil
+		}
+		return typeHelpers.ToString(val), nil
+	case "JSON", "JSONB":
+		bytes, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return sqlString(string(bytes)), nil
+	case "DATE":
+		if t, ok := val.(time.Time); ok {
+			return sqlString(t.Format("2006-01-02")), nil
+		}
+	case "TIMESTAMP":
+		if t, ok := val.(time.Time); ok {
+			return sqlString(t.Format("2006-01-02 15:04:05.999999")), nil
+		}
+	case "TIMESTAMPTZ":
+		if t, ok := val.(time.Time); ok {
+			return sqlString(t.Format("2006-01-02 15:04:05.999999Z07:00")), nil
+		}
+	}
+
+	str, err := ColumnValueAsString(val, colType)
+	if err != nil {
+		return "", err
+	}
+	return sqlString(str), nil
+}
@@ -15,6 +15,7 @@ const (
 	versionFileName             = "versions.json"
 	databaseRunningInfoFileName = "steampipe.json"
 	pluginManagerStateFileName  = "plugin_manager.json"
+	templateVersionFileName     = "templates.json"
 )
 
 var SteampipeDir string
@@ -52,6 +53,11 @@ func EnsureTemplateDir() string {
 	return ensureSteampipeSubDir(filepath.Join("check", "templates"))
 }
 
+// EnsureTemplateBackupDir returns the path to the directory used to store modified templates before they are updated (creates if missing)
+func EnsureTemplateBackupDir() string {
+	return ensureSteampipeSubDir(filepath.Join("check", "templates_backup"))
+}
+
 // EnsurePluginDir returns the path to the plugins directory (creates if missing)
 func EnsurePluginDir() string {
 	return ensureSteampipeSubDir("plugins")
@@ -81,6 +87,11 @@ func EnsureDashboardAssetsDir() string {
 	return ensureSteampipeSubDir(filepath.Join(filepath.Join("report", "assets")))
 }
 
+// EnsureDashboardResultsDir returns the path to the directory used to store the results of scheduled dashboard server runs (creates if missing)
+func EnsureDashboardResultsDir() string {
+	return ensureSteampipeSubDir(filepath.Join("dashboard", "results"))
+}
+
 // ConnectionStatePath returns the path of the connections state file
 func ConnectionStatePath() string {
 	return filepath.Join(EnsureInternalDir(), connectionsStateFileName)
@@ -110,6 +121,11 @@ func RunningInfoFilePath() string {
 	return filepath.Join(EnsureInternalDir(), databaseRunningInfoFileName)
 }
 
+// TemplateVersionFilePath returns the path of the file recording the versions of the installed check templates
+func TemplateVersionFilePath() string {
+	return filepath.Join(EnsureInternalDir(), templateVersionFileName)
+}
+
 func PluginManagerStateFilePath() string {
 	return filepath.Join(EnsureInternalDir(), pluginManagerStateFileName)
 }
@@ -352,7 +352,7 @@ func (c *InteractiveClient) executor(ctx context.Context, line string) {
 	line = strings.TrimSpace(line)
 	// store the history (the raw line which was entered)
 	// we want to store even if we fail to resolve a query
-	c.interactiveQueryHistory.Push(line)
+	historyEntry := c.interactiveQueryHistory.Push(line)
 
 	query, err := c.getQuery(ctx, line)
 	if query == "" {
@@ -369,6 +369,7 @@ func (c *InteractiveClient) executor(ctx context.Context, line string) {
 
 	if metaquery.IsMetaQuery(query) {
 		if err := c.executeMetaquery(queryContext, query); err != nil {
+			historyEntry.SetError(err)
 			utils.ShowError(ctx, err)
 		}
 		// cancel the context
@@ -376,11 +377,8 @@ func (c *InteractiveClient) executor(ctx context.Context, line string) {
 
 	} else {
 		// otherwise execute query
-		result, err := c.client().Execute(queryContext, query)
-		if err != nil {
+		if err := c.executeQuery(queryContext, query, historyEntry); err != nil {
 			utils.ShowError(ctx, utils.HandleCancelError(err))
-		} else {
-			c.resultsStreamer.StreamResult(result)
 		}
 	}
 
@@ -473,6 +471,8 @@ func (c *InteractiveClient) executeMetaquery(ctx context.Context, query string)
 		Connections: client.ConnectionMap(),
 		Prompt:      c.interactivePrompt,
 		ClosePrompt: func() { c.afterClose = AfterPromptCloseExit },
+		History:     c.interactiveQueryHistory,
+		RerunQuery:  c.rerunQuery,
 	})
 }
 
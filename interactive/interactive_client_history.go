@@ -0,0 +1,92 @@
+package interactive
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/query/metaquery"
+	"github.com/turbot/steampipe/query/queryhistory"
+	"github.com/turbot/steampipe/query/queryresult"
+)
+
+// execute the query, streaming the results to the display and recording the execution metadata in the history entry
+func (c *InteractiveClient) executeQuery(ctx context.Context, query string, historyEntry *queryhistory.HistoryEntry) error {
+	result, err := c.client().Execute(ctx, query)
+	if err != nil {
+		historyEntry.SetError(err)
+		return err
+	}
+	if historyEntry == nil {
+		c.resultsStreamer.StreamResult(result)
+		return nil
+	}
+
+	recordedResult, recordComplete := recordResult(result, historyEntry)
+	c.resultsStreamer.StreamResult(recordedResult)
+	// wait for all rows to be recorded
+	<-recordComplete
+
+	historyEntry.Workspace = viper.GetString(constants.ArgWorkspaceChDir)
+	// failure to retrieve the search path is not fatal - we just do not record it
+	if searchPath, err := c.client().GetCurrentSearchPath(ctx); err == nil {
+		historyEntry.SearchPath = helpers.RemoveFromStringSlice(searchPath, constants.FunctionSchema)
+	}
+	return nil
+}
+
+// rerunQuery re-executes a line from the query history - this is called by the .history metaquery
+func (c *InteractiveClient) rerunQuery(ctx context.Context, line string) error {
+	// the re-run is added to the history as a new execution
+	historyEntry := c.interactiveQueryHistory.Push(line)
+
+	query, _, err := c.workspace().ResolveQueryAndArgs(line)
+	if err != nil {
+		historyEntry.SetError(err)
+		return err
+	}
+	if metaquery.IsMetaQuery(query) {
+		err = c.executeMetaquery(ctx, query)
+		historyEntry.SetError(err)
+		return err
+	}
+	return c.executeQuery(ctx, query, historyEntry)
+}
+
+// wrap the result in a result which records the row count, error and duration in the history entry
+// the returned channel is closed once the underlying result has been fully read
+func recordResult(result *queryresult.Result, historyEntry *queryhistory.HistoryEntry) (*queryresult.Result, chan struct{}) {
+	recordedResult := queryresult.NewQueryResult(result.ColTypes)
+	recordComplete := make(chan struct{})
+
+	go func() {
+		defer close(recordComplete)
+
+		streaming := true
+		for row := range *result.RowChan {
+			// once an error has been streamed the display stops reading rows
+			// - continue to drain the underlying result so the session is released, but do not forward
+			if !streaming {
+				continue
+			}
+			if row.Error != nil {
+				historyEntry.SetError(row.Error)
+				streaming = false
+			} else {
+				historyEntry.RowCount++
+			}
+			*recordedResult.RowChan <- row
+		}
+		// the duration is sent before the row channel is closed (it is not sent at all if reading the rows failed)
+		select {
+		case duration := <-result.Duration:
+			historyEntry.Duration = duration
+			recordedResult.Duration <- duration
+		default:
+		}
+		recordedResult.Close()
+	}()
+
+	return recordedResult, recordComplete
+}
@@ -91,29 +91,37 @@ func (r *SteampipeImageRef) GetOrgNameAndStream() (string, string, string) {
 //      dockerhub.org/myimage@mytag
 //		aws@1.0.0
 //		hub.steampipe.io/plugin/turbot/aws@1.0.0
+//		registry.internal/org/plugin:1.2
+//		localhost:5000/org/plugin:1.2
 
 func getFullImageRef(imagePath string) string {
 
 	tag := DefaultImageTag
 
 	// Get the tag, default to `latest`
-	items := strings.Split(imagePath, ":")
-	if len(items) > 1 {
-		tag = items[1]
+	// the tag follows the final path segment - the registry host may include a port (localhost:5000/org/plugin:1.0)
+	path := imagePath
+	if idx := strings.LastIndex(imagePath, ":"); idx > strings.LastIndex(imagePath, "/") {
+		path, tag = imagePath[:idx], imagePath[idx+1:]
 	}
 
 	// Image path
-	parts := strings.Split(items[0], "/")
+	parts := strings.Split(path, "/")
 	switch len(parts) {
 	case 1: //ex:  aws
 		return fmt.Sprintf("%s/%s/%s/%s:%s", DefaultImageRepoActualURL, DefaultImageType, DefaultImageOrg, parts[len(parts)-1], tag)
-	case 2: //ex:   turbot/aws OR dockerhub.com/my-image
+	case 2: //ex:   turbot/aws OR dockerhub.com/my-image OR localhost:5000/my-image
 		org := parts[len(parts)-2]
-		if strings.Contains(org, ".") {
-			return fmt.Sprintf("%s:%s", items[0], tag)
+		if isRegistryHost(org) {
+			return fmt.Sprintf("%s:%s", path, tag)
 		}
 		return fmt.Sprintf("%s/%s/%s/%s:%s", DefaultImageRepoActualURL, DefaultImageType, org, parts[len(parts)-1], tag)
-	default: //ex: us-docker.pkg.dev/steampipe/plugin/turbot/aws
-		return fmt.Sprintf("%s:%s", items[0], tag)
+	default: //ex: us-docker.pkg.dev/steampipe/plugin/turbot/aws OR registry.internal/org/plugin
+		return fmt.Sprintf("%s:%s", path, tag)
 	}
 }
+
+// isRegistryHost returns whether the first part of an image path is a registry host rather than an org
+func isRegistryHost(s string) bool {
+	return strings.ContainsAny(s, ".:") || s == "localhost"
+}
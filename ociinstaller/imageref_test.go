@@ -31,6 +31,14 @@ func TestActualImageRef(t *testing.T) {
 		"us-docker.pkg.dev/steampipe/plugins/turbot/aws@latest": "us-docker.pkg.dev/steampipe/plugins/turbot/aws:latest",
 		"hub.steampipe.io/plugins/turbot/aws@latest":            "us-docker.pkg.dev/steampipe/plugins/turbot/aws:latest",
 		"hub.steampipe.io/plugins/someoneelse/myimage@mytag":    "us-docker.pkg.dev/steampipe/plugins/someoneelse/myimage:mytag",
+
+		"registry.internal/org/plugin:1.2": "registry.internal/org/plugin:1.2",
+		"registry.internal/org/plugin":     "registry.internal/org/plugin:latest",
+		"registry.internal/org/plugin@1.2": "registry.internal/org/plugin:1.2",
+		"localhost:5000/org/plugin:1.2":    "localhost:5000/org/plugin:1.2",
+		"localhost:5000/plugin":            "localhost:5000/plugin:latest",
+		"localhost@5000/org/plugin@1.2":    "localhost:5000/org/plugin:1.2",
+		"local/my-plugin":                  "us-docker.pkg.dev/steampipe/plugins/local/my-plugin:latest",
 	}
 
 	for testCase, want := range cases {
@@ -73,6 +81,11 @@ func TestDisplayImageRef(t *testing.T) {
 		"us-docker.pkg.dev/steampipe/plugins/turbot/aws@latest": "hub.steampipe.io/plugins/turbot/aws@latest",
 		"hub.steampipe.io/plugins/turbot/aws@latest":            "hub.steampipe.io/plugins/turbot/aws@latest",
 		"hub.steampipe.io/plugins/someoneelse/myimage@mytag":    "hub.steampipe.io/plugins/someoneelse/myimage@mytag",
+
+		"registry.internal/org/plugin:1.2": "registry.internal/org/plugin@1.2",
+		"registry.internal/org/plugin":     "registry.internal/org/plugin@latest",
+		"localhost:5000/org/plugin:1.2":    "localhost@5000/org/plugin@1.2",
+		"local/my-plugin":                  "hub.steampipe.io/plugins/local/my-plugin@latest",
 	}
 
 	for testCase, want := range cases {
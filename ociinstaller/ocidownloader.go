@@ -21,8 +21,17 @@ func NewOciDownloader() *ociDownloader {
 	// oras uses containerd, which uses logrus and is set up to log
 	// warning and above.  Set to ErrrLevel to get rid of unwanted error message
 	logrus.SetLevel(logrus.ErrorLevel)
+
+	// use credentials from the env or docker config for registries which require authentication
+	// (registries on localhost are accessed over plain http)
+	authorizer := docker.NewDockerAuthorizer(docker.WithAuthCreds(registryCredentials))
 	return &ociDownloader{
-		resolver: docker.NewResolver(docker.ResolverOptions{}),
+		resolver: docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(
+				docker.WithAuthorizer(authorizer),
+				docker.WithPlainHTTP(docker.MatchLocalhost),
+			),
+		}),
 	}
 }
 
@@ -35,13 +35,13 @@ func InstallPlugin(ctx context.Context, imageRef string) (*SteampipeImage, error
 		return nil, fmt.Errorf("plugin installation failed: %s", err)
 	}
 
-	if err := updateVersionFilePlugin(image); err != nil {
+	if err := updateVersionFilePlugin(image, ref.ActualImageRef()); err != nil {
 		return nil, err
 	}
 	return image, nil
 }
 
-func updateVersionFilePlugin(image *SteampipeImage) error {
+func updateVersionFilePlugin(image *SteampipeImage, installedFrom string) error {
 	timeNow := versionfile.FormatTime(time.Now())
 	v, err := versionfile.LoadPluginVersionFile()
 	if err != nil {
@@ -61,7 +61,7 @@ func updateVersionFilePlugin(image *SteampipeImage) error {
 	plugin.Name = pluginFullName
 	plugin.Version = image.Config.Plugin.Version
 	plugin.ImageDigest = string(image.OCIDescriptor.Digest)
-	plugin.InstalledFrom = ref.ActualImageRef()
+	plugin.InstalledFrom = installedFrom
 	plugin.LastCheckedDate = timeNow
 	plugin.InstallDate = timeNow
 
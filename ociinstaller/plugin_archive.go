@@ -0,0 +1,180 @@
+package ociinstaller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/turbot/steampipe/constants"
+)
+
+const (
+	// LocalPluginOrg is the org used for plugins installed from a local archive
+	LocalPluginOrg = "local"
+	// LocalPluginVersion is the version recorded for plugins installed from a local archive
+	LocalPluginVersion = "local"
+)
+
+// IsPluginArchive returns whether the given plugin arg is a local plugin archive (a .tar.gz or .tgz file)
+func IsPluginArchive(plugin string) bool {
+	lower := strings.ToLower(plugin)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// PluginArchiveImageRef returns the image ref a local plugin archive is installed as
+// the plugin name is the archive file name without the extension or 'steampipe-plugin-' prefix,
+// and the org is 'local', e.g. ./steampipe-plugin-foo.tar.gz is installed as local/foo
+func PluginArchiveImageRef(archivePath string) string {
+	name := filepath.Base(archivePath)
+	lower := strings.ToLower(name)
+	for _, extension := range []string{".tar.gz", ".tgz"} {
+		if strings.HasSuffix(lower, extension) {
+			name = name[:len(name)-len(extension)]
+			break
+		}
+	}
+	name = strings.TrimPrefix(name, "steampipe-plugin-")
+
+	return fmt.Sprintf("%s/%s", LocalPluginOrg, name)
+}
+
+// InstallPluginArchive installs a plugin from a local archive (a gzipped tar file)
+// The archive must contain a single plugin binary (with the extension .plugin) and may contain
+// 'docs' and 'config' folders alongside the binary, as are included in a plugin image
+func InstallPluginArchive(ctx context.Context, archivePath string) (*SteampipeImage, error) {
+	archivePath, err := filepath.Abs(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		return nil, fmt.Errorf("plugin archive %s not found", archivePath)
+	}
+
+	tempDir := NewTempDir(archivePath)
+	defer tempDir.Delete()
+
+	if err := untar(archivePath, tempDir.Path); err != nil {
+		return nil, fmt.Errorf("could not extract plugin archive %s: %s", archivePath, err)
+	}
+
+	archiveDigest, err := fileDigest(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	pluginImage, err := getArchivePluginImage(tempDir.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	imageRef := PluginArchiveImageRef(archivePath)
+	_, name, _ := NewSteampipeImageRef(imageRef).GetOrgNameAndStream()
+	image := &SteampipeImage{
+		OCIDescriptor: &ocispec.Descriptor{Digest: archiveDigest},
+		ImageRef:      imageRef,
+		Config: &config{
+			Plugin: &configPlugin{
+				Name:         name,
+				Organization: LocalPluginOrg,
+				Version:      LocalPluginVersion,
+			},
+		},
+		Plugin: pluginImage,
+	}
+
+	if err = installPluginArchiveBinary(image, tempDir.Path); err != nil {
+		return nil, fmt.Errorf("plugin installation failed: %s", err)
+	}
+	if err = installPluginDocs(image, tempDir.Path); err != nil {
+		return nil, fmt.Errorf("plugin installation failed: %s", err)
+	}
+	if err = installPluginConfigFiles(image, tempDir.Path); err != nil {
+		return nil, fmt.Errorf("plugin installation failed: %s", err)
+	}
+
+	if err := updateVersionFilePlugin(image, archivePath); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// find the plugin binary in the extracted archive, along with any docs and config folders
+// return a PluginImage with paths relative to the extraction folder
+func getArchivePluginImage(extractedDir string) (*PluginImage, error) {
+	var binaries []string
+	err := filepath.Walk(extractedDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == constants.PluginExtension {
+			binaries = append(binaries, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(binaries) != 1 {
+		return nil, fmt.Errorf("plugin archive should contain a single plugin file. %d plugins were found", len(binaries))
+	}
+
+	binaryFile, err := filepath.Rel(extractedDir, binaries[0])
+	if err != nil {
+		return nil, err
+	}
+	res := &PluginImage{BinaryFile: binaryFile}
+
+	// docs and config files are in the same folder as the binary
+	binaryDir := filepath.Dir(binaryFile)
+	if info, err := os.Stat(filepath.Join(extractedDir, binaryDir, "docs")); err == nil && info.IsDir() {
+		res.DocsDir = filepath.Join(binaryDir, "docs")
+	}
+	if info, err := os.Stat(filepath.Join(extractedDir, binaryDir, "config")); err == nil && info.IsDir() {
+		res.ConfigFileDir = filepath.Join(binaryDir, "config")
+	}
+	return res, nil
+}
+
+func installPluginArchiveBinary(image *SteampipeImage, tempdir string) error {
+	installTo := pluginInstallDir(image.ImageRef)
+
+	// remove any previously installed binary - the plugin folder must contain a single plugin file
+	entries, err := os.ReadDir(installTo)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == constants.PluginExtension {
+			if err := os.Remove(filepath.Join(installTo, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	sourcePath := filepath.Join(tempdir, image.Plugin.BinaryFile)
+	destPath := filepath.Join(installTo, filepath.Base(image.Plugin.BinaryFile))
+	if err := moveFileWithinPartition(sourcePath, destPath); err != nil {
+		return fmt.Errorf("could not move %s to %s", sourcePath, destPath)
+	}
+	// ensure the plugin is executable
+	return os.Chmod(destPath, 0755)
+}
+
+func fileDigest(path string) (digest.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return digest.NewDigest(digest.SHA256, h), nil
+}
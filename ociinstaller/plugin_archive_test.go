@@ -0,0 +1,43 @@
+package ociinstaller
+
+import (
+	"testing"
+)
+
+func TestPluginArchiveImageRef(t *testing.T) {
+	cases := map[string]string{
+		"./my-plugin.tar.gz":                   "local/my-plugin",
+		"my-plugin.tgz":                        "local/my-plugin",
+		"/tmp/plugins/MyPlugin.TAR.GZ":         "local/MyPlugin",
+		"./steampipe-plugin-inhouse.tar.gz":    "local/inhouse",
+		"../dist/steampipe-plugin-foo_1.2.tgz": "local/foo_1.2",
+	}
+
+	for testCase, want := range cases {
+		t.Run(testCase, func(t *testing.T) {
+			if !IsPluginArchive(testCase) {
+				t.Errorf("IsPluginArchive failed for case '%s': expected true", testCase)
+			}
+			if got := PluginArchiveImageRef(testCase); got != want {
+				t.Errorf("PluginArchiveImageRef failed for case '%s': expected %s, got %s", testCase, want, got)
+			}
+		})
+	}
+}
+
+func TestIsPluginArchive(t *testing.T) {
+	cases := []string{
+		"aws",
+		"turbot/aws@1.0.0",
+		"registry.internal/org/plugin:1.2",
+		"./my-plugin.plugin",
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase, func(t *testing.T) {
+			if IsPluginArchive(testCase) {
+				t.Errorf("IsPluginArchive failed for case '%s': expected false", testCase)
+			}
+		})
+	}
+}
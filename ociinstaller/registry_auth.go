@@ -0,0 +1,166 @@
+package ociinstaller
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+// the key used for Docker Hub in the docker config file
+const dockerHubConfigKey = "https://index.docker.io/v1/"
+
+type dockerConfig struct {
+	Auths       map[string]dockerAuthConfig `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+type dockerAuthConfig struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// registryCredentials returns the username and secret to use for the given registry host
+// credentials are resolved in order of precedence (high to low):
+// - the STEAMPIPE_REGISTRY_USERNAME and STEAMPIPE_REGISTRY_PASSWORD env vars, if the host is STEAMPIPE_REGISTRY_HOST
+// - the docker config file ($DOCKER_CONFIG/config.json or ~/.docker/config.json), including credential helpers
+// NOTE: the env var credentials are only sent to the configured host - any registry which issues an auth challenge
+// would otherwise receive them
+func registryCredentials(host string) (string, string, error) {
+	if registryHost := os.Getenv(constants.EnvRegistryHost); registryHost != "" && strings.EqualFold(registryHost, host) {
+		username, password := os.Getenv(constants.EnvRegistryUsername), os.Getenv(constants.EnvRegistryPassword)
+		if username != "" && password != "" {
+			return username, password, nil
+		}
+	}
+
+	config, err := loadDockerConfig()
+	if err != nil {
+		// do not fail the install - the registry may not need credentials
+		log.Printf("[WARN] failed to load docker config: %s", err)
+		return "", "", nil
+	}
+	if config == nil {
+		return "", "", nil
+	}
+	return config.credentials(host)
+}
+
+func loadDockerConfig() (*dockerConfig, error) {
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		configDir = filepath.Join(homeDir, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	config := &dockerConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %s", err)
+	}
+	return config, nil
+}
+
+func (c *dockerConfig) credentials(host string) (string, string, error) {
+	configKey := host
+	if host == "docker.io" || host == "registry-1.docker.io" {
+		configKey = dockerHubConfigKey
+	}
+
+	// a credential helper for this registry takes precedence
+	if helper, ok := c.CredHelpers[configKey]; ok {
+		return credentialHelperCredentials(helper, configKey)
+	}
+
+	// now look for credentials stored in the config file itself
+	for key, auth := range c.Auths {
+		if configKey != dockerHubConfigKey {
+			// keys may include a scheme and path, e.g. https://registry.internal/v1/
+			key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+			key = strings.Split(key, "/")[0]
+		}
+		if key != configKey {
+			continue
+		}
+		if username, password, ok := auth.credentials(); ok {
+			return username, password, nil
+		}
+	}
+
+	// finally try the default credential store
+	if c.CredsStore != "" {
+		return credentialHelperCredentials(c.CredsStore, configKey)
+	}
+	return "", "", nil
+}
+
+func (a dockerAuthConfig) credentials() (string, string, bool) {
+	// an identity token is used as a refresh token, with no username
+	if a.IdentityToken != "" {
+		return "", a.IdentityToken, true
+	}
+	if a.Username != "" && a.Password != "" {
+		return a.Username, a.Password, true
+	}
+	if a.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+		if err != nil {
+			log.Printf("[WARN] failed to decode docker config auth: %s", err)
+			return "", "", false
+		}
+		if parts := strings.SplitN(string(decoded), ":", 2); len(parts) == 2 {
+			return parts[0], parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+// execute the docker credential helper 'docker-credential-<helper>' to retrieve the credentials for the registry
+func credentialHelperCredentials(helper, serverURL string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(fmt.Sprintf("docker-credential-%s", helper), "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// the helper reports missing credentials on stdout
+		output := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(output, "credentials not found") {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("docker credential helper '%s' failed: %s %s", helper, err, output)
+	}
+
+	var res struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &res); err != nil {
+		return "", "", fmt.Errorf("failed to parse output of docker credential helper '%s': %s", helper, err)
+	}
+	// '<token>' is used as the username for identity tokens
+	if res.Username == "<token>" {
+		return "", res.Secret, nil
+	}
+	return res.Username, res.Secret, nil
+}
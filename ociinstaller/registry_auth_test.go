@@ -0,0 +1,94 @@
+package ociinstaller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+type registryCredentialsTest struct {
+	host     string
+	env      map[string]string
+	username string
+	secret   string
+}
+
+const testDockerConfig = `{
+	"auths": {
+		"registry.internal": {"auth": "dXNlcjpwYXNzOndvcmQ="},
+		"https://other.internal/v1/": {"username": "other", "password": "secret"},
+		"token.internal": {"identitytoken": "my-token"},
+		"https://index.docker.io/v1/": {"auth": "aHViOmh1YnBhc3M="}
+	}
+}`
+
+var testCasesRegistryCredentials = map[string]registryCredentialsTest{
+	"auth field": {
+		host:     "registry.internal",
+		username: "user",
+		secret:   "pass:word",
+	},
+	"username and password with url key": {
+		host:     "other.internal",
+		username: "other",
+		secret:   "secret",
+	},
+	"identity token": {
+		host:   "token.internal",
+		secret: "my-token",
+	},
+	"docker hub": {
+		host:     "registry-1.docker.io",
+		username: "hub",
+		secret:   "hubpass",
+	},
+	"unknown registry": {
+		host: "unknown.internal",
+	},
+	"env overrides docker config": {
+		host:     "registry.internal",
+		env:      map[string]string{constants.EnvRegistryUsername: "env-user", constants.EnvRegistryPassword: "env-pass", constants.EnvRegistryHost: "registry.internal"},
+		username: "env-user",
+		secret:   "env-pass",
+	},
+	"env not sent to default registry": {
+		host: "us-docker.pkg.dev",
+		env:  map[string]string{constants.EnvRegistryUsername: "env-user", constants.EnvRegistryPassword: "env-pass", constants.EnvRegistryHost: "registry.internal"},
+	},
+	"env not sent to other registry": {
+		host: "ghcr.io",
+		env:  map[string]string{constants.EnvRegistryUsername: "env-user", constants.EnvRegistryPassword: "env-pass", constants.EnvRegistryHost: "registry.internal"},
+	},
+	"env not sent without host": {
+		host:     "registry.internal",
+		env:      map[string]string{constants.EnvRegistryUsername: "env-user", constants.EnvRegistryPassword: "env-pass"},
+		username: "user",
+		secret:   "pass:word",
+	},
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	configDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(testDockerConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCKER_CONFIG", configDir)
+
+	for name, test := range testCasesRegistryCredentials {
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []string{constants.EnvRegistryUsername, constants.EnvRegistryPassword, constants.EnvRegistryHost} {
+				t.Setenv(k, test.env[k])
+			}
+
+			username, secret, err := registryCredentials(test.host)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if username != test.username || secret != test.secret {
+				t.Errorf("expected %s/%s, got %s/%s", test.username, test.secret, username, secret)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package ociinstaller
 
 import (
+	"archive/tar"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func ungzip(sourceFile string, destDir string) (string, error) {
@@ -37,6 +39,62 @@ func ungzip(sourceFile string, destDir string) (string, error) {
 	return destFile, nil
 }
 
+// untar extracts the gzipped tar file sourceFile into destDir
+func untar(sourceFile string, destDir string) error {
+	r, err := os.Open(sourceFile)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	uncompressedStream, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer uncompressedStream.Close()
+
+	tarReader := tar.NewReader(uncompressedStream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// do not allow entries to be written outside the destination folder
+		// (archives created from a folder, e.g. 'tar -czf plugin.tar.gz -C dist .', contain an entry for the folder itself - './')
+		destPath := filepath.Join(destDir, header.Name)
+		if destPath == filepath.Clean(destDir) {
+			continue
+		}
+		if !strings.HasPrefix(destPath, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path in archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(destPath, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+				return err
+			}
+			outFile, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(outFile, tarReader); err != nil {
+				outFile.Close()
+				return err
+			}
+			outFile.Close()
+		}
+	}
+}
+
 func fileExists(filePath string) bool {
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return false
@@ -0,0 +1,113 @@
+package ociinstaller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	content  string
+}
+
+// writeTestArchive writes a gzipped tar file containing the given entries and returns its path
+func writeTestArchive(t *testing.T, entries []tarEntry) string {
+	archivePath := filepath.Join(t.TempDir(), "plugin.tar.gz")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Mode: 0755, Size: int64(len(entry.content))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return archivePath
+}
+
+type untarTest struct {
+	entries []tarEntry
+	// the files expected to be extracted, relative to the destination folder
+	expected    []string
+	expectedErr bool
+}
+
+var testCasesUntar = map[string]untarTest{
+	"folder rooted archive": {
+		// as created by 'tar -czf plugin.tar.gz -C dist .'
+		entries: []tarEntry{
+			{name: "./", typeflag: tar.TypeDir},
+			{name: "./steampipe-plugin-foo.plugin", typeflag: tar.TypeReg, content: "binary"},
+			{name: "./docs/", typeflag: tar.TypeDir},
+			{name: "./docs/index.md", typeflag: tar.TypeReg, content: "# Foo"},
+		},
+		expected: []string{"steampipe-plugin-foo.plugin", "docs/index.md"},
+	},
+	"plain archive": {
+		entries: []tarEntry{
+			{name: "steampipe-plugin-foo.plugin", typeflag: tar.TypeReg, content: "binary"},
+		},
+		expected: []string{"steampipe-plugin-foo.plugin"},
+	},
+	"path traversal": {
+		entries: []tarEntry{
+			{name: "../steampipe-plugin-foo.plugin", typeflag: tar.TypeReg, content: "binary"},
+		},
+		expectedErr: true,
+	},
+	"nested path traversal": {
+		entries: []tarEntry{
+			{name: "./docs/../../steampipe-plugin-foo.plugin", typeflag: tar.TypeReg, content: "binary"},
+		},
+		expectedErr: true,
+	},
+}
+
+func TestUntar(t *testing.T) {
+	for name, test := range testCasesUntar {
+		archivePath := writeTestArchive(t, test.entries)
+		// extract into a subfolder so any file written outside it can be detected
+		destDir := filepath.Join(t.TempDir(), "dest")
+		if err := os.Mkdir(destDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		err := untar(archivePath, destDir)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected error", name)
+			}
+			if fileExists(filepath.Join(filepath.Dir(destDir), "steampipe-plugin-foo.plugin")) {
+				t.Errorf("Test: '%s'' FAILED : file was written outside the destination folder", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+		for _, file := range test.expected {
+			if !fileExists(filepath.Join(destDir, file)) {
+				t.Errorf("Test: '%s'' FAILED : expected file %s to be extracted", name, file)
+			}
+		}
+	}
+}
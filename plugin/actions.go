@@ -99,7 +99,11 @@ func Exists(plugin string) (bool, error) {
 }
 
 // Install installs a plugin in the local file system
+// the plugin may be an image ref or the path to a local plugin archive
 func Install(ctx context.Context, plugin string) (*ociinstaller.SteampipeImage, error) {
+	if ociinstaller.IsPluginArchive(plugin) {
+		return ociinstaller.InstallPluginArchive(ctx, plugin)
+	}
 	image, err := ociinstaller.InstallPlugin(ctx, plugin)
 	return image, err
 }
@@ -46,7 +46,7 @@ func GetUpdateReport(installationID string, check []*versionfile.InstalledVersio
 	versionChecker.signature = installationID
 
 	for _, c := range check {
-		if strings.HasPrefix(c.Name, ociinstaller.DefaultImageRepoDisplayURL) {
+		if shouldCheckForUpdate(c) {
 			versionChecker.pluginsToCheck = append(versionChecker.pluginsToCheck, c)
 		}
 	}
@@ -67,7 +67,7 @@ func GetAllUpdateReport(installationID string) map[string]VersionCheckReport {
 	}
 
 	for _, p := range versionFileData.Plugins {
-		if strings.HasPrefix(p.Name, ociinstaller.DefaultImageRepoDisplayURL) {
+		if shouldCheckForUpdate(p) {
 			versionChecker.pluginsToCheck = append(versionChecker.pluginsToCheck, p)
 		}
 	}
@@ -75,6 +75,12 @@ func GetAllUpdateReport(installationID string) map[string]VersionCheckReport {
 	return versionChecker.reportPluginUpdates()
 }
 
+// only plugins installed from the Steampipe registry are checked for updates
+// (plugins installed from a local archive are also installed under the registry display url)
+func shouldCheckForUpdate(plugin *versionfile.InstalledVersion) bool {
+	return strings.HasPrefix(plugin.Name, ociinstaller.DefaultImageRepoDisplayURL) && !ociinstaller.IsPluginArchive(plugin.InstalledFrom)
+}
+
 func (v *VersionChecker) reportPluginUpdates() map[string]VersionCheckReport {
 	versionFileData, err := versionfile.LoadPluginVersionFile()
 	if err != nil {
@@ -23,6 +23,10 @@ func (c *GRPCClient) Shutdown(req *pb.ShutdownRequest) (*pb.ShutdownResponse, er
 	return c.client.Shutdown(c.ctx, req)
 }
 
+func (c *GRPCClient) Status(req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	return c.client.Status(c.ctx, req)
+}
+
 // GRPCServer is the gRPC server that GRPCClient talks to.
 type GRPCServer struct {
 	pb.UnimplementedPluginManagerServer
@@ -37,3 +41,7 @@ func (m *GRPCServer) Get(_ context.Context, req *pb.GetRequest) (*pb.GetResponse
 func (m *GRPCServer) Shutdown(_ context.Context, req *pb.ShutdownRequest) (*pb.ShutdownResponse, error) {
 	return m.Impl.Shutdown(req)
 }
+
+func (m *GRPCServer) Status(_ context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	return m.Impl.Status(req)
+}
@@ -27,6 +27,7 @@ var Handshake = plugin.HandshakeConfig{
 type PluginManager interface {
 	Get(req *pb.GetRequest) (*pb.GetResponse, error)
 	Shutdown(req *pb.ShutdownRequest) (*pb.ShutdownResponse, error)
+	Status(req *pb.StatusRequest) (*pb.StatusResponse, error)
 }
 
 // PluginManagerPlugin is the implementation of plugin.GRPCServer so we can serve/consume this.
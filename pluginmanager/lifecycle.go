@@ -113,6 +113,23 @@ func GetPluginManager() (pluginshared.PluginManager, error) {
 	return getPluginManager(true)
 }
 
+// GetStatus returns the status of the plugins run by the plugin manager
+// if the plugin manager is not running, nil is returned (the plugin manager is not started)
+func GetStatus() (*pb.StatusResponse, error) {
+	state, err := LoadPluginManagerState()
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || !state.Running {
+		return nil, nil
+	}
+	pluginManager, err := NewPluginManagerClient(state)
+	if err != nil {
+		return nil, err
+	}
+	return pluginManager.Status(&pb.StatusRequest{})
+}
+
 // getPluginManager determines whether the plugin manager is running
 // if not,and if startIfNeeded is true, it starts the manager
 // it then returns a plugin manager client
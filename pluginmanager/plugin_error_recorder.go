@@ -0,0 +1,77 @@
+package pluginmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// pluginErrorRecorder is passed as the stderr writer of a plugin process
+// it records the most recent error logged by the plugin - this is reported as the crash reason if the plugin exits
+type pluginErrorRecorder struct {
+	mut sync.Mutex
+	// the incomplete line being written
+	line      []byte
+	lastError string
+	// once a panic has been seen, the panic message is retained in preference to any subsequent output
+	panicked bool
+}
+
+func newPluginErrorRecorder() *pluginErrorRecorder {
+	return &pluginErrorRecorder{}
+}
+
+// Write implements io.Writer
+func (r *pluginErrorRecorder) Write(p []byte) (int, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.line = append(r.line, p...)
+	for {
+		idx := bytes.IndexByte(r.line, '\n')
+		if idx == -1 {
+			break
+		}
+		r.recordLine(string(r.line[:idx]))
+		r.line = r.line[idx+1:]
+	}
+	return len(p), nil
+}
+
+func (r *pluginErrorRecorder) recordLine(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || r.panicked {
+		return
+	}
+	switch {
+	case strings.HasPrefix(line, "panic:") || strings.HasPrefix(line, "fatal error:"):
+		r.lastError = line
+		r.panicked = true
+	case strings.HasPrefix(line, "[ERROR]"):
+		r.lastError = strings.TrimSpace(strings.TrimPrefix(line, "[ERROR]"))
+	case strings.HasPrefix(line, "{"):
+		// the plugin logs in hclog JSON format
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return
+		}
+		if level, _ := entry["@level"].(string); level != "error" {
+			return
+		}
+		if message, ok := entry["@message"].(string); ok {
+			r.lastError = message
+		}
+	}
+}
+
+// crashReason returns the most recent error logged by the plugin
+func (r *pluginErrorRecorder) crashReason() string {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.lastError == "" {
+		return "plugin process exited unexpectedly"
+	}
+	return r.lastError
+}
@@ -0,0 +1,68 @@
+package pluginmanager
+
+import "testing"
+
+type recordLineTest struct {
+	lines    []string
+	expected string
+}
+
+var testCasesRecordLine = map[string]recordLineTest{
+	"no output": {
+		lines:    nil,
+		expected: "plugin process exited unexpectedly",
+	},
+	"no errors": {
+		lines:    []string{"[INFO] starting", "[WARN] slow query", ""},
+		expected: "plugin process exited unexpectedly",
+	},
+	"error": {
+		lines:    []string{"[ERROR] first error", "[INFO] retrying", "[ERROR]  second error "},
+		expected: "second error",
+	},
+	"json error": {
+		lines:    []string{`{"@level":"info","@message":"starting"}`, `{"@level":"error","@message":"connection failed"}`},
+		expected: "connection failed",
+	},
+	"json non error": {
+		lines:    []string{"[ERROR] connection failed", `{"@level":"warn","@message":"slow query"}`},
+		expected: "connection failed",
+	},
+	"invalid json": {
+		lines:    []string{"[ERROR] connection failed", `{"@level":"error"`},
+		expected: "connection failed",
+	},
+	"panic": {
+		lines:    []string{"[ERROR] connection failed", "panic: runtime error: index out of range", "[ERROR] after panic"},
+		expected: "panic: runtime error: index out of range",
+	},
+	"fatal error": {
+		lines:    []string{"fatal error: concurrent map writes", `{"@level":"error","@message":"after fatal error"}`},
+		expected: "fatal error: concurrent map writes",
+	},
+}
+
+func TestRecordLine(t *testing.T) {
+	for name, test := range testCasesRecordLine {
+		recorder := newPluginErrorRecorder()
+		for _, line := range test.lines {
+			recorder.recordLine(line)
+		}
+		if reason := recorder.crashReason(); reason != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected crash reason '%s', got '%s'", name, test.expected, reason)
+		}
+	}
+}
+
+func TestPluginErrorRecorderWrite(t *testing.T) {
+	recorder := newPluginErrorRecorder()
+	// a line may be split across writes - only complete lines are recorded
+	recorder.Write([]byte("[INFO] starting\n[ERROR] conn"))
+	if reason := recorder.crashReason(); reason != "plugin process exited unexpectedly" {
+		t.Errorf("TestPluginErrorRecorderWrite FAILED : expected no error before the line is complete, got '%s'", reason)
+	}
+	recorder.Write([]byte("ection failed\n"))
+	if reason := recorder.crashReason(); reason != "connection failed" {
+		t.Errorf("TestPluginErrorRecorderWrite FAILED : expected 'connection failed', got '%s'", reason)
+	}
+}
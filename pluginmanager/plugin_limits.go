@@ -0,0 +1,99 @@
+package pluginmanager
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	psutils "github.com/shirou/gopsutil/process"
+)
+
+const (
+	// the interval at which running plugins are checked for idleness and memory usage
+	pluginCheckInterval = 10 * time.Second
+	// the CPU time a plugin process must use between checks to be considered in use
+	// (an idle plugin process uses a small amount of CPU for the go runtime)
+	pluginIdleCPUThreshold = 0.5
+)
+
+// evictIfIdle stops the plugin if it has not been used for longer than the idle timeout of the connection
+// a plugin is in use if it has been requested by a Get call, or if its process has used CPU time since the last check
+// (connections which have already attached to the plugin execute queries without calling Get)
+// returns whether the plugin was evicted
+func (m *PluginManager) evictIfIdle(connection string, p *runningPlugin) bool {
+	idleTimeout := time.Duration(m.getConnectionConfig(connection).GetIdleTimeout()) * time.Second
+	if idleTimeout == 0 {
+		return false
+	}
+	cpuTime, err := m.processCPUTime(p.reattach.Pid)
+	if err != nil {
+		log.Printf("[TRACE] failed to get CPU time for connection '%s', pid %d: %s", connection, p.reattach.Pid, err.Error())
+		return false
+	}
+
+	m.mut.Lock()
+	if cpuTime-p.cpuTime > pluginIdleCPUThreshold {
+		p.cpuTime = cpuTime
+		p.lastUsed = time.Now()
+	}
+	idle := !m.shuttingDown && m.Plugins[connection] == p && time.Since(p.lastUsed) > idleTimeout
+	if idle {
+		// remove from the map so the next Get starts a new plugin
+		delete(m.Plugins, connection)
+	}
+	m.mut.Unlock()
+
+	if !idle {
+		return false
+	}
+	log.Printf("[INFO] plugin for connection '%s' has been idle for %s - stopping", connection, idleTimeout)
+	p.client.Kill()
+	return true
+}
+
+// checkMemoryLimit checks the memory usage of the plugin process against the memory limit of the connection
+// if the limit is exceeded, the reason is returned
+func (m *PluginManager) checkMemoryLimit(connection string, p *runningPlugin) string {
+	memoryMaxMb := m.getConnectionConfig(connection).GetMemoryMaxMb()
+	if memoryMaxMb == 0 {
+		return ""
+	}
+	memory, err := m.processMemory(p.reattach.Pid)
+	if err != nil {
+		log.Printf("[TRACE] failed to get memory usage for connection '%s', pid %d: %s", connection, p.reattach.Pid, err.Error())
+		return ""
+	}
+	memoryMb := int64(memory / (1024 * 1024))
+	if memoryMb <= memoryMaxMb {
+		return ""
+	}
+	reason := fmt.Sprintf("plugin memory usage %dMB exceeded the limit of %dMB", memoryMb, memoryMaxMb)
+	log.Printf("[WARN] %s for connection '%s' - restarting", reason, connection)
+	return reason
+}
+
+// processCPUTime returns the total CPU time (user and system) used by the given process, in seconds
+func processCPUTime(pid int64) (float64, error) {
+	process, err := psutils.NewProcess(int32(pid))
+	if err != nil {
+		return 0, err
+	}
+	times, err := process.Times()
+	if err != nil {
+		return 0, err
+	}
+	return times.User + times.System, nil
+}
+
+// processMemory returns the resident memory of the given process, in bytes
+func processMemory(pid int64) (uint64, error) {
+	process, err := psutils.NewProcess(int32(pid))
+	if err != nil {
+		return 0, err
+	}
+	memoryInfo, err := process.MemoryInfo()
+	if err != nil {
+		return 0, err
+	}
+	return memoryInfo.RSS, nil
+}
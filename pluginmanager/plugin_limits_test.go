@@ -0,0 +1,163 @@
+package pluginmanager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	pb "github.com/turbot/steampipe/pluginmanager/grpc/proto"
+)
+
+const testConnection = "c1"
+
+func newTestPluginManager(config *pb.ConnectionConfig, p *runningPlugin) *PluginManager {
+	return &PluginManager{
+		Plugins:          map[string]*runningPlugin{testConnection: p},
+		connectionConfig: map[string]*pb.ConnectionConfig{testConnection: config},
+	}
+}
+
+type evictIfIdleTest struct {
+	idleTimeout int64
+	// the time since the plugin was last used
+	idleFor time.Duration
+	// the CPU time used by the plugin process since the last check
+	cpuTimeUsed float64
+	cpuTimeErr  bool
+	// if set, the map entry for the connection is not the checked plugin
+	replaced     bool
+	shuttingDown bool
+	expected     bool
+}
+
+var testCasesEvictIfIdle = map[string]evictIfIdleTest{
+	"eviction disabled": {
+		idleTimeout: 0,
+		idleFor:     time.Hour,
+		expected:    false,
+	},
+	"idle": {
+		idleTimeout: 60,
+		idleFor:     2 * time.Minute,
+		expected:    true,
+	},
+	"recently used": {
+		idleTimeout: 60,
+		idleFor:     30 * time.Second,
+		expected:    false,
+	},
+	"executing queries": {
+		idleTimeout: 60,
+		idleFor:     2 * time.Minute,
+		cpuTimeUsed: 2,
+		expected:    false,
+	},
+	"runtime cpu usage": {
+		idleTimeout: 60,
+		idleFor:     2 * time.Minute,
+		cpuTimeUsed: pluginIdleCPUThreshold / 2,
+		expected:    true,
+	},
+	"cpu time unavailable": {
+		idleTimeout: 60,
+		idleFor:     2 * time.Minute,
+		cpuTimeErr:  true,
+		expected:    false,
+	},
+	"already replaced": {
+		idleTimeout: 60,
+		idleFor:     2 * time.Minute,
+		replaced:    true,
+		expected:    false,
+	},
+	"shutting down": {
+		idleTimeout:  60,
+		idleFor:      2 * time.Minute,
+		shuttingDown: true,
+		expected:     false,
+	},
+}
+
+func TestEvictIfIdle(t *testing.T) {
+	for name, test := range testCasesEvictIfIdle {
+		p := &runningPlugin{
+			client:   &plugin.Client{},
+			reattach: &pb.ReattachConfig{Pid: 100},
+			lastUsed: time.Now().Add(-test.idleFor),
+			cpuTime:  10,
+		}
+		m := newTestPluginManager(&pb.ConnectionConfig{IdleTimeout: test.idleTimeout}, p)
+		m.shuttingDown = test.shuttingDown
+		if test.replaced {
+			m.Plugins[testConnection] = &runningPlugin{}
+		}
+		m.processCPUTime = func(pid int64) (float64, error) {
+			if test.cpuTimeErr {
+				return 0, fmt.Errorf("process %d not found", pid)
+			}
+			return p.cpuTime + test.cpuTimeUsed, nil
+		}
+
+		evicted := m.evictIfIdle(testConnection, p)
+		if evicted != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected evicted %v, got %v", name, test.expected, evicted)
+			continue
+		}
+		if _, inMap := m.Plugins[testConnection]; inMap == evicted {
+			t.Errorf("Test: '%s'' FAILED : expected the plugin to be removed from the map only if evicted", name)
+		}
+	}
+}
+
+type checkMemoryLimitTest struct {
+	memoryMaxMb int64
+	memoryMb    uint64
+	memoryErr   bool
+	expected    string
+}
+
+var testCasesCheckMemoryLimit = map[string]checkMemoryLimitTest{
+	"no limit": {
+		memoryMaxMb: 0,
+		memoryMb:    4096,
+		expected:    "",
+	},
+	"under limit": {
+		memoryMaxMb: 1024,
+		memoryMb:    512,
+		expected:    "",
+	},
+	"at limit": {
+		memoryMaxMb: 1024,
+		memoryMb:    1024,
+		expected:    "",
+	},
+	"over limit": {
+		memoryMaxMb: 1024,
+		memoryMb:    1025,
+		expected:    "plugin memory usage 1025MB exceeded the limit of 1024MB",
+	},
+	"memory unavailable": {
+		memoryMaxMb: 1024,
+		memoryErr:   true,
+		expected:    "",
+	},
+}
+
+func TestCheckMemoryLimit(t *testing.T) {
+	for name, test := range testCasesCheckMemoryLimit {
+		p := &runningPlugin{reattach: &pb.ReattachConfig{Pid: 100}}
+		m := newTestPluginManager(&pb.ConnectionConfig{MemoryMaxMb: test.memoryMaxMb}, p)
+		m.processMemory = func(pid int64) (uint64, error) {
+			if test.memoryErr {
+				return 0, fmt.Errorf("process %d not found", pid)
+			}
+			return test.memoryMb * 1024 * 1024, nil
+		}
+
+		if reason := m.checkMemoryLimit(testConnection, p); reason != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected reason '%s', got '%s'", name, test.expected, reason)
+		}
+	}
+}
@@ -1,15 +1,18 @@
 package pluginmanager
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	psutils "github.com/shirou/gopsutil/process"
 	"github.com/turbot/go-kit/helpers"
 	sdkshared "github.com/turbot/steampipe-plugin-sdk/v3/grpc/shared"
 	pb "github.com/turbot/steampipe/pluginmanager/grpc/proto"
@@ -17,10 +20,26 @@ import (
 	"github.com/turbot/steampipe/utils"
 )
 
+// the error returned when a plugin is requested or started after Shutdown has been called
+var errShuttingDown = errors.New("plugin manager is shutting down")
+
 type runningPlugin struct {
 	client      *plugin.Client
 	reattach    *pb.ReattachConfig
 	initialized chan (bool)
+	startTime   time.Time
+	// records errors written by the plugin process
+	errors *pluginErrorRecorder
+	// the number of times the plugin process has been restarted after exiting
+	restartCount int64
+	// the number of consecutive times the plugin has crashed, and the reason for the last crash
+	failureCount int
+	lastError    string
+	// set if the plugin has crashed too many times to be restarted
+	degraded bool
+	// the time the plugin was last requested or was seen to be executing, and the CPU time of the process at that point
+	lastUsed time.Time
+	cpuTime  float64
 }
 
 // PluginManager is the real implementation of grpc.PluginManager
@@ -31,6 +50,11 @@ type PluginManager struct {
 	mut              sync.Mutex
 	connectionConfig map[string]*pb.ConnectionConfig
 	logger           hclog.Logger
+	// set once Shutdown is called - plugins which exit are no longer restarted
+	shuttingDown bool
+	// used to read the CPU time and memory usage of plugin processes - may be overridden by tests
+	processCPUTime func(pid int64) (float64, error)
+	processMemory  func(pid int64) (uint64, error)
 }
 
 func NewPluginManager(connectionConfig map[string]*pb.ConnectionConfig, logger hclog.Logger) *PluginManager {
@@ -38,6 +62,8 @@ func NewPluginManager(connectionConfig map[string]*pb.ConnectionConfig, logger h
 		logger:           logger,
 		connectionConfig: connectionConfig,
 		Plugins:          make(map[string]*runningPlugin),
+		processCPUTime:   processCPUTime,
+		processMemory:    processMemory,
 	}
 	return pluginManager
 }
@@ -107,17 +133,30 @@ func (m *PluginManager) getPlugin(connection string) (_ *pb.ReattachConfig, err
 	// is this plugin already running
 	// lock access to plugin map
 	m.mut.Lock()
+	if m.shuttingDown {
+		m.mut.Unlock()
+		return nil, errShuttingDown
+	}
 	p, ok := m.Plugins[connection]
 
 	if ok {
 		// unlock access to map
 		m.mut.Unlock()
 
+		// if the plugin has crashed too many times, do not try to start it
+		if p.degraded {
+			return nil, degradedError(connection, p)
+		}
+
 		// so we have the plugin in our map - is it started?
 		err = m.waitForPluginLoad(connection, p)
 		if err != nil {
 			return nil, err
 		}
+		// if the plugin failed to restart, the map entry will have been replaced - try again
+		if p.reattach == nil {
+			return m.getPlugin(connection)
+		}
 		log.Printf("[TRACE] connection %s is loaded, check for running PID", connection)
 
 		// ok so the plugin should now be running
@@ -129,16 +168,24 @@ func (m *PluginManager) getPlugin(connection string) (_ *pb.ReattachConfig, err
 		if exists {
 			// so the plugin is good
 			log.Printf("[TRACE] PluginManager found '%s' in map, pid %d, reattach %v", connection, reattach.Pid, reattach)
+			m.mut.Lock()
+			p.lastUsed = time.Now()
+			m.mut.Unlock()
 
 			// return the reattach config
 			return reattach, nil
 		}
 
 		//  either the pid does not exist or the plugin has exited
-		// remove from map
-		m.mut.Lock()
-		delete(m.Plugins, connection)
-		m.mut.Unlock()
+		// record the crash - this replaces the map entry with a placeholder for the restarted plugin
+		placeholder := m.pluginCrashed(connection, p, p.errors.crashReason())
+		if placeholder == nil {
+			// the plugin supervisor has already handled the crash - wait for the restarted plugin
+			return m.getPlugin(connection)
+		}
+		if placeholder.degraded {
+			return nil, degradedError(connection, placeholder)
+		}
 		// update reason
 		reason = fmt.Sprintf("PluginManager found pid %d for connection '%s' in plugin map but plugin process does not exist - killing client and removing from map", reattach.Pid, connection)
 
@@ -158,33 +205,49 @@ func (m *PluginManager) getPlugin(connection string) (_ *pb.ReattachConfig, err
 	// log the startup reason
 	log.Printf("[TRACE] %s", reason)
 	// so we need to start the plugin
-	client, err := m.startPlugin(connection)
+	client, errors, err := m.startPlugin(connection)
 	if err != nil {
 		return nil, err
 	}
 
 	// store the client to our map
-	reattach := m.storeClientToMap(connection, client)
+	reattach, err := m.storeClientToMap(connection, client, errors)
+	if err != nil {
+		return nil, err
+	}
 	log.Printf("[TRACE] PluginManager Get complete, returning reattach config with PID: %d", reattach.Pid)
 
 	// and return
 	return reattach, nil
 }
 
-// create reattach config for plugin, store to map and close initialized channel
-func (m *PluginManager) storeClientToMap(connection string, client *plugin.Client) *pb.ReattachConfig {
+// create reattach config for plugin, store to map, close initialized channel and start supervising the plugin
+// if the plugin manager has started shutting down since the plugin was started, the plugin is killed and an error returned
+func (m *PluginManager) storeClientToMap(connection string, client *plugin.Client, errors *pluginErrorRecorder) (*pb.ReattachConfig, error) {
 	// lock access to map
 	m.mut.Lock()
 	defer m.mut.Unlock()
 
+	if m.shuttingDown {
+		client.Kill()
+		// release any callers waiting for the plugin to start
+		close(m.Plugins[connection].initialized)
+		return nil, errShuttingDown
+	}
+
 	reattach := pb.NewReattachConfig(client.ReattachConfig())
 	p := m.Plugins[connection]
 	p.client = client
 	p.reattach = reattach
+	p.startTime = time.Now()
+	p.lastUsed = p.startTime
+	p.errors = errors
 	m.Plugins[connection] = p
 	// mark as initialized
 	close(p.initialized)
-	return reattach
+
+	go m.supervisePlugin(connection, p)
+	return reattach, nil
 }
 
 func (m *PluginManager) SetConnectionConfigMap(configMap map[string]*pb.ConnectionConfig) {
@@ -192,6 +255,20 @@ func (m *PluginManager) SetConnectionConfigMap(configMap map[string]*pb.Connecti
 	defer m.mut.Unlock()
 
 	m.connectionConfig = configMap
+	// the connection config has changed - allow degraded connections to be retried
+	for connection, p := range m.Plugins {
+		if p.degraded {
+			delete(m.Plugins, connection)
+		}
+	}
+}
+
+// getConnectionConfig returns the config of the given connection (or nil if the connection does not exist)
+func (m *PluginManager) getConnectionConfig(connection string) *pb.ConnectionConfig {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	return m.connectionConfig[connection]
 }
 
 func (m *PluginManager) Shutdown(req *pb.ShutdownRequest) (resp *pb.ShutdownResponse, err error) {
@@ -205,26 +282,88 @@ func (m *PluginManager) Shutdown(req *pb.ShutdownRequest) (resp *pb.ShutdownResp
 		}
 	}()
 
+	// stop the plugin supervisors from restarting the plugins
+	m.shuttingDown = true
 	for _, p := range m.Plugins {
+		// skip plugins which are not running
+		if p.client == nil {
+			continue
+		}
 		log.Printf("[TRACE] killing plugin %v", p.reattach.Pid)
 		p.client.Kill()
 	}
 	return &pb.ShutdownResponse{}, nil
 }
 
-func (m *PluginManager) startPlugin(connection string) (*plugin.Client, error) {
+// Status returns the status of all running plugins
+func (m *PluginManager) Status(req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	log.Printf("[TRACE] PluginManager Status")
+
+	m.mut.Lock()
+	resp := &pb.StatusResponse{}
+	for connection, p := range m.Plugins {
+		// skip plugins which are starting for the first time
+		if p.reattach == nil && p.failureCount == 0 {
+			continue
+		}
+		status := &pb.PluginStatus{
+			Connection:   connection,
+			RestartCount: p.restartCount,
+			Status:       p.status(),
+			LastError:    p.lastError,
+		}
+		if p.reattach != nil {
+			status.Pid = p.reattach.Pid
+			status.StartTime = p.startTime.Unix()
+		}
+		if connectionConfig, ok := m.connectionConfig[connection]; ok {
+			status.Plugin = connectionConfig.Plugin
+		}
+		resp.Plugins = append(resp.Plugins, status)
+	}
+	m.mut.Unlock()
+
+	// populate the resource usage without holding the lock
+	for _, status := range resp.Plugins {
+		if status.Pid != 0 {
+			setProcessUsage(status)
+		}
+	}
+	sort.Slice(resp.Plugins, func(i, j int) bool {
+		return resp.Plugins[i].Connection < resp.Plugins[j].Connection
+	})
+	return resp, nil
+}
+
+// setProcessUsage sets the memory and CPU usage of the plugin process
+// if the process cannot be found (e.g. it has exited), these are left unset
+func setProcessUsage(status *pb.PluginStatus) {
+	process, err := psutils.NewProcess(int32(status.Pid))
+	if err != nil {
+		log.Printf("[TRACE] failed to find process for connection %s, pid %d: %s", status.Connection, status.Pid, err.Error())
+		return
+	}
+	if memoryInfo, err := process.MemoryInfo(); err == nil {
+		status.MemoryBytes = memoryInfo.RSS
+	}
+	if cpuPercent, err := process.CPUPercent(); err == nil {
+		status.CpuPercent = cpuPercent
+	}
+}
+
+func (m *PluginManager) startPlugin(connection string) (*plugin.Client, *pluginErrorRecorder, error) {
 
 	log.Printf("[TRACE] ************ start plugin %s ********************\n", connection)
 
 	// get connection config
 	connectionConfig, ok := m.connectionConfig[connection]
 	if !ok {
-		return nil, fmt.Errorf("no config loaded for connection %s", connection)
+		return nil, nil, fmt.Errorf("no config loaded for connection %s", connection)
 	}
 
 	pluginPath, err := GetPluginPath(connectionConfig.Plugin, connectionConfig.PluginShortName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// create the plugin map
@@ -237,6 +376,8 @@ func (m *PluginManager) startPlugin(connection string) (*plugin.Client, error) {
 
 	// pass env to command
 	cmd.Env = os.Environ()
+	// record any errors the plugin writes - these are reported if the plugin crashes
+	errors := newPluginErrorRecorder()
 	client := plugin.NewClient(&plugin.ClientConfig{
 		HandshakeConfig:  sdkshared.Handshake,
 		Plugins:          pluginMap,
@@ -244,12 +385,13 @@ func (m *PluginManager) startPlugin(connection string) (*plugin.Client, error) {
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 		// pass our logger to the plugin client to ensure plugin logs end up in logfile
 		Logger: m.logger,
+		Stderr: errors,
 	})
 
 	if _, err := client.Start(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return client, nil
+	return client, errors, nil
 }
 
 func (m *PluginManager) waitForPluginLoad(connection string, p *runningPlugin) error {
@@ -257,7 +399,7 @@ func (m *PluginManager) waitForPluginLoad(connection string, p *runningPlugin) e
 
 	select {
 	case <-p.initialized:
-		log.Printf("[TRACE] initialized: %d", p.reattach.Pid)
+		log.Printf("[TRACE] initialized: %s", connection)
 		return nil
 
 	case <-time.After(time.Duration(pluginStartTimeoutSecs) * time.Second):
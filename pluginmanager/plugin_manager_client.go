@@ -72,3 +72,7 @@ func (c *PluginManagerClient) Shutdown(req *pb.ShutdownRequest) (res *pb.Shutdow
 	log.Printf("[TRACE] PluginManagerClient Shutdown")
 	return c.manager.Shutdown(req)
 }
+
+func (c *PluginManagerClient) Status(req *pb.StatusRequest) (res *pb.StatusResponse, err error) {
+	return c.manager.Status(req)
+}
@@ -0,0 +1,133 @@
+package pluginmanager
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// the interval at which running plugins are checked for exit
+	pluginPollInterval = 1 * time.Second
+	// the delay before the first restart of a crashed plugin - this doubles with each consecutive failure
+	pluginRestartBackoff = 1 * time.Second
+	// a plugin which crashes this many consecutive times is marked as degraded and is not restarted
+	maxPluginFailures = 3
+	// a plugin which runs for longer than this before crashing is not considered to be repeatedly failing
+	pluginStableDuration = 1 * time.Minute
+)
+
+// plugin states
+const (
+	PluginStatusRunning    = "running"
+	PluginStatusRestarting = "restarting"
+	PluginStatusDegraded   = "degraded"
+)
+
+// supervisePlugin waits for the plugin process to exit and, unless the plugin manager is shutting down,
+// restarts it with backoff. A new supervisor is started for the restarted plugin by storeClientToMap
+// while the plugin is running, it is stopped if it becomes idle and restarted if it exceeds its memory limit
+func (m *PluginManager) supervisePlugin(connection string, p *runningPlugin) {
+	var reason string
+	lastCheck := time.Now()
+	for reason == "" && !p.client.Exited() {
+		time.Sleep(pluginPollInterval)
+		if time.Since(lastCheck) < pluginCheckInterval {
+			continue
+		}
+		lastCheck = time.Now()
+		if m.evictIfIdle(connection, p) {
+			return
+		}
+		reason = m.checkMemoryLimit(connection, p)
+	}
+
+	if reason == "" {
+		reason = p.errors.crashReason()
+	} else {
+		// the plugin has exceeded its memory limit
+		p.client.Kill()
+	}
+	for {
+		placeholder := m.pluginCrashed(connection, p, reason)
+		if placeholder == nil || placeholder.degraded {
+			return
+		}
+		time.Sleep(restartBackoff(placeholder.failureCount))
+
+		log.Printf("[TRACE] PluginManager restarting plugin for connection '%s'", connection)
+		client, errors, err := m.startPlugin(connection)
+		if err == nil {
+			// if the plugin manager has shut down during the restart, this kills the restarted plugin
+			if _, err := m.storeClientToMap(connection, client, errors); err != nil {
+				log.Printf("[TRACE] restarted plugin for connection '%s' was not stored: %s", connection, err.Error())
+			}
+			return
+		}
+		// treat a failure to start as a further crash
+		reason = err.Error()
+		p = placeholder
+	}
+}
+
+// pluginCrashed records the crash of the plugin for the given connection and replaces its map entry
+// with either a placeholder for the restarted plugin or, if the plugin has failed too many times, a degraded entry
+// if the crash has already been handled (or the plugin manager is shutting down), nil is returned
+func (m *PluginManager) pluginCrashed(connection string, p *runningPlugin, reason string) *runningPlugin {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.shuttingDown || m.Plugins[connection] != p {
+		return nil
+	}
+
+	failureCount := p.failureCount + 1
+	// if the plugin ran successfully for a while, this is not a repeated failure
+	if !p.startTime.IsZero() && time.Since(p.startTime) > pluginStableDuration {
+		failureCount = 1
+	}
+	log.Printf("[WARN] plugin for connection '%s' exited (failure %d of %d): %s", connection, failureCount, maxPluginFailures, reason)
+
+	res := &runningPlugin{
+		initialized:  make(chan (bool), 1),
+		restartCount: p.restartCount,
+		failureCount: failureCount,
+		lastError:    reason,
+	}
+	if failureCount >= maxPluginFailures {
+		log.Printf("[WARN] connection '%s' is degraded - its plugin will not be restarted until the connection config is reloaded", connection)
+		res.degraded = true
+		// nothing will be started, so do not make callers wait
+		close(res.initialized)
+	} else {
+		res.restartCount++
+	}
+	// if the crashed entry was a placeholder for a plugin which failed to start, release any callers waiting for it
+	if p.client == nil {
+		close(p.initialized)
+	}
+	m.Plugins[connection] = res
+	return res
+}
+
+// degradedError returns the error returned to callers requesting a degraded connection
+func degradedError(connection string, p *runningPlugin) error {
+	return fmt.Errorf("connection '%s' is degraded - its plugin has crashed %d times: %s", connection, p.failureCount, p.lastError)
+}
+
+// the delay before restarting a plugin which has failed the given number of consecutive times
+func restartBackoff(failureCount int) time.Duration {
+	return pluginRestartBackoff * time.Duration(1<<(failureCount-1))
+}
+
+// the status of the plugin reported by the Status RPC
+func (p *runningPlugin) status() string {
+	switch {
+	case p.degraded:
+		return PluginStatusDegraded
+	case p.reattach == nil:
+		return PluginStatusRestarting
+	default:
+		return PluginStatusRunning
+	}
+}
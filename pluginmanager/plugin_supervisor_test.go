@@ -0,0 +1,150 @@
+package pluginmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	testCases := map[int]time.Duration{
+		1: 1 * time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+	}
+	for failureCount, expected := range testCases {
+		if backoff := restartBackoff(failureCount); backoff != expected {
+			t.Errorf("Test: '%d'' FAILED : expected backoff %s, got %s", failureCount, expected, backoff)
+		}
+	}
+}
+
+type pluginCrashedTest struct {
+	failureCount int
+	startTime    time.Time
+	// if set, the map entry for the connection is not the crashed plugin
+	alreadyHandled bool
+	shuttingDown   bool
+	// if nil, no placeholder is expected
+	expected *runningPlugin
+}
+
+var testCasesPluginCrashed = map[string]pluginCrashedTest{
+	"first crash": {
+		failureCount: 0,
+		startTime:    time.Now(),
+		expected:     &runningPlugin{failureCount: 1, restartCount: 1},
+	},
+	"second crash": {
+		failureCount: 1,
+		startTime:    time.Now(),
+		expected:     &runningPlugin{failureCount: 2, restartCount: 1},
+	},
+	"degraded": {
+		failureCount: maxPluginFailures - 1,
+		startTime:    time.Now(),
+		expected:     &runningPlugin{failureCount: maxPluginFailures, restartCount: 0, degraded: true},
+	},
+	"crash after stable run": {
+		failureCount: maxPluginFailures - 1,
+		startTime:    time.Now().Add(-2 * pluginStableDuration),
+		expected:     &runningPlugin{failureCount: 1, restartCount: 1},
+	},
+	"placeholder failed to start": {
+		failureCount: 1,
+		expected:     &runningPlugin{failureCount: 2, restartCount: 1},
+	},
+	"already handled": {
+		failureCount:   0,
+		alreadyHandled: true,
+	},
+	"shutting down": {
+		failureCount: 0,
+		shuttingDown: true,
+	},
+}
+
+func TestPluginCrashed(t *testing.T) {
+	const connection = "c1"
+	for name, test := range testCasesPluginCrashed {
+		crashed := &runningPlugin{
+			initialized:  make(chan (bool), 1),
+			startTime:    test.startTime,
+			failureCount: test.failureCount,
+		}
+		m := &PluginManager{
+			Plugins:      map[string]*runningPlugin{connection: crashed},
+			shuttingDown: test.shuttingDown,
+		}
+		if test.alreadyHandled {
+			m.Plugins[connection] = &runningPlugin{}
+		}
+
+		res := m.pluginCrashed(connection, crashed, "plugin process exited unexpectedly")
+		if test.expected == nil {
+			if res != nil {
+				t.Errorf("Test: '%s'' FAILED : expected no placeholder, got %+v", name, res)
+			}
+			continue
+		}
+		if res == nil {
+			t.Errorf("Test: '%s'' FAILED : expected a placeholder, got nil", name)
+			continue
+		}
+		if res.failureCount != test.expected.failureCount || res.restartCount != test.expected.restartCount || res.degraded != test.expected.degraded {
+			t.Errorf("Test: '%s'' FAILED : expected failureCount %d, restartCount %d, degraded %v, got %d, %d, %v",
+				name, test.expected.failureCount, test.expected.restartCount, test.expected.degraded, res.failureCount, res.restartCount, res.degraded)
+		}
+		if res.lastError != "plugin process exited unexpectedly" {
+			t.Errorf("Test: '%s'' FAILED : expected the crash reason to be recorded, got '%s'", name, res.lastError)
+		}
+		if m.Plugins[connection] != res {
+			t.Errorf("Test: '%s'' FAILED : expected the placeholder to replace the crashed plugin", name)
+		}
+		// callers waiting on a degraded connection must not block
+		if res.degraded && !isClosed(res.initialized) {
+			t.Errorf("Test: '%s'' FAILED : expected the initialized channel of a degraded placeholder to be closed", name)
+		}
+		// the crashed entry had no client, so callers waiting for it to start must be released
+		if !isClosed(crashed.initialized) {
+			t.Errorf("Test: '%s'' FAILED : expected the initialized channel of the crashed placeholder to be closed", name)
+		}
+	}
+}
+
+func isClosed(c chan (bool)) bool {
+	select {
+	case _, ok := <-c:
+		return !ok
+	default:
+		return false
+	}
+}
+
+func TestStoreClientToMapShuttingDown(t *testing.T) {
+	const connection = "c1"
+	placeholder := &runningPlugin{initialized: make(chan (bool), 1)}
+	m := &PluginManager{
+		Plugins: map[string]*runningPlugin{connection: placeholder},
+	}
+	// Shutdown is called while the plugin is being restarted
+	if _, err := m.Shutdown(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	reattach, err := m.storeClientToMap(connection, &plugin.Client{}, newPluginErrorRecorder())
+	if err != errShuttingDown || reattach != nil {
+		t.Errorf("TestStoreClientToMapShuttingDown FAILED : expected error '%v', got '%v'", errShuttingDown, err)
+	}
+	if placeholder.client != nil {
+		t.Errorf("TestStoreClientToMapShuttingDown FAILED : expected the plugin not to be stored")
+	}
+	if !isClosed(placeholder.initialized) {
+		t.Errorf("TestStoreClientToMapShuttingDown FAILED : expected callers waiting for the plugin to be released")
+	}
+	// callers requesting the plugin must not start a new one
+	if _, err := m.getPlugin(connection); err != errShuttingDown {
+		t.Errorf("TestStoreClientToMapShuttingDown FAILED : expected getPlugin to return '%v', got '%v'", errShuttingDown, err)
+	}
+}
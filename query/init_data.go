@@ -10,13 +10,14 @@ import (
 	"github.com/turbot/steampipe/db/db_client"
 	"github.com/turbot/steampipe/db/db_common"
 	"github.com/turbot/steampipe/db/db_local"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/utils"
 	"github.com/turbot/steampipe/workspace"
 )
 
 type InitData struct {
 	Loaded    chan struct{}
-	Queries   []string
+	Queries   []*modconfig.ResolvedQuery
 	Workspace *workspace.Workspace
 	Client    db_common.Client
 	Result    *db_common.InitResult
@@ -87,14 +87,17 @@ func init() {
 		},
 		constants.CmdOutput: {
 			title:       constants.CmdOutput,
-			handler:     setViperConfigFromArg(constants.ArgOutput),
-			validator:   composeValidator(exactlyNArgs(1), validatorFromArgsOf(constants.CmdOutput)),
-			description: "Set output format: csv, json or table",
+			handler:     setOutput,
+			validator:   outputValidator,
+			description: "Set output format: csv, json, table, line, md, html or sql",
 			args: []metaQueryArg{
 				{value: constants.OutputFormatJSON, description: "Set output to JSON"},
 				{value: constants.OutputFormatCSV, description: "Set output to CSV"},
 				{value: constants.OutputFormatTable, description: "Set output to Table"},
 				{value: constants.OutputFormatLine, description: "Set output to Line"},
+				{value: constants.OutputFormatMarkdown, description: "Set output to a Markdown table"},
+				{value: constants.OutputFormatHTML, description: "Set output to an HTML table"},
+				{value: constants.OutputFormatSQL, description: "Set output to SQL INSERT statements, optionally passing the target table"},
 			},
 			completer: completerFromArgsOf(constants.CmdOutput),
 		},
@@ -138,6 +141,27 @@ func init() {
 			validator:   atMostNArgs(1),
 			description: "Display the current search path, or set the search-path by passing in a comma-separated list",
 		},
+		constants.CmdHistory: {
+			title:       constants.CmdHistory,
+			handler:     showHistory,
+			validator:   historyValidator,
+			description: "List, search or re-run previous queries",
+			args: []metaQueryArg{
+				{value: constants.ArgSearch, description: "Search the history: .history search <text> [since=<date|duration>] [status=ok|error]"},
+				{value: constants.ArgRun, description: "Re-run a query from the history: .history run <index>"},
+			},
+			completer: completerFromArgsOf(constants.CmdHistory),
+		},
+		constants.CmdDiff: {
+			title:       constants.CmdDiff,
+			handler:     setDiff,
+			validator:   diffValidator,
+			description: "Show the differences of query results from a previous JSON result: .diff <file> [key columns], or .diff off",
+			args: []metaQueryArg{
+				{value: constants.ArgOff, description: "Turn off result diffing"},
+			},
+			completer: completerFromArgsOf(constants.CmdDiff),
+		},
 		constants.CmdSearchPathPrefix: {
 			title:       constants.CmdSearchPathPrefix,
 			handler:     setSearchPathPrefix,
@@ -3,6 +3,8 @@ package metaquery
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -15,6 +17,8 @@ import (
 	"github.com/turbot/steampipe/cmdconfig"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/pluginmanager"
+	"github.com/turbot/steampipe/query/queryhistory"
 	"github.com/turbot/steampipe/schema"
 	"github.com/turbot/steampipe/steampipeconfig"
 )
@@ -38,6 +42,9 @@ type HandlerInput struct {
 	Connections *steampipeconfig.ConnectionDataMap
 	Prompt      *prompt.Prompt
 	ClosePrompt func()
+	History     *queryhistory.QueryHistory
+	// RerunQuery executes a query from the history
+	RerunQuery func(ctx context.Context, query string) error
 }
 type PromptControl interface {
 	Clear()
@@ -149,6 +156,39 @@ func setViperConfigFromArg(viperKey string) handler {
 	}
 }
 
+// set the ArgOutput viper key - for sql output the target table may also be passed
+func setOutput(ctx context.Context, input *HandlerInput) error {
+	args := input.args()
+	cmdconfig.Viper().Set(constants.ArgOutput, strings.ToLower(args[0]))
+	if len(args) > 1 {
+		cmdconfig.Viper().Set(constants.ArgSQLTable, args[1])
+	}
+	return nil
+}
+
+// set (or clear) the previous result to diff query results against, and the key columns used to match rows
+func setDiff(ctx context.Context, input *HandlerInput) error {
+	args := input.args()
+	if strings.ToLower(args[0]) == constants.ArgOff {
+		cmdconfig.Viper().Set(constants.ArgDiffAgainst, "")
+		cmdconfig.Viper().Set(constants.ArgDiffKey, []string{})
+		return nil
+	}
+	if _, err := os.Stat(args[0]); err != nil {
+		return fmt.Errorf("cannot diff against '%s': %v", args[0], err)
+	}
+
+	var keyColumns []string
+	if len(args) > 1 {
+		for _, column := range strings.Split(args[1], ",") {
+			keyColumns = append(keyColumns, strings.TrimSpace(column))
+		}
+	}
+	cmdconfig.Viper().Set(constants.ArgDiffAgainst, args[0])
+	cmdconfig.Viper().Set(constants.ArgDiffKey, keyColumns)
+	return nil
+}
+
 // exit
 func doExit(ctx context.Context, input *HandlerInput) error {
 	input.ClosePrompt()
@@ -306,18 +346,19 @@ To get information about the columns in a table, run %s
 }
 
 func listConnections(ctx context.Context, input *HandlerInput) error {
-	header := []string{"connection", "plugin"}
+	header := []string{"connection", "plugin", "state"}
 	rows := [][]string{}
 
+	pluginStates := getPluginStates()
 	for _, schema := range input.Schema.GetSchemas() {
 		if schema == input.Schema.TemporarySchemaName {
 			continue
 		}
 		plugin, found := (*input.Connections)[schema]
 		if found {
-			rows = append(rows, []string{schema, plugin.Plugin})
+			rows = append(rows, []string{schema, plugin.Plugin, pluginStates[schema]})
 		} else {
-			rows = append(rows, []string{schema, ""})
+			rows = append(rows, []string{schema, "", pluginStates[schema]})
 		}
 	}
 
@@ -337,6 +378,28 @@ To get information about the columns in a table, run %s
 	return nil
 }
 
+// getPluginStates returns a map of connection name to the state of its plugin process
+// degraded connections include the reason for the last plugin crash
+func getPluginStates() map[string]string {
+	res := make(map[string]string)
+	status, err := pluginmanager.GetStatus()
+	if err != nil {
+		log.Printf("[WARN] failed to get plugin manager status: %s", err.Error())
+		return res
+	}
+	if status == nil {
+		return res
+	}
+	for _, p := range status.Plugins {
+		if p.Status == pluginmanager.PluginStatusDegraded {
+			res[p.Connection] = fmt.Sprintf("%s: %s", p.Status, p.LastError)
+		} else {
+			res[p.Connection] = p.Status
+		}
+	}
+	return res
+}
+
 func inspectConnection(connectionName string, input *HandlerInput) bool {
 	header := []string{"table", "description"}
 	rows := [][]string{}
@@ -0,0 +1,143 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/query/queryhistory"
+)
+
+// list, search or re-run the query history
+func showHistory(ctx context.Context, input *HandlerInput) error {
+	if input.History == nil {
+		return fmt.Errorf("query history is not available")
+	}
+	args := input.args()
+	if len(args) == 0 {
+		entries := input.History.Entries()
+		// show the most recent entries
+		var indices []int
+		for i := len(entries) - constants.HistoryDisplaySize; i < len(entries); i++ {
+			if i >= 0 {
+				indices = append(indices, i+1)
+			}
+		}
+		displayHistory(input.History, indices)
+		return nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case constants.ArgSearch:
+		filter, err := parseHistoryFilter(args[1:], time.Now())
+		if err != nil {
+			return err
+		}
+		displayHistory(input.History, input.History.Search(filter))
+		return nil
+	case constants.ArgRun:
+		return rerunHistoryEntry(ctx, input, args[1])
+	}
+	return fmt.Errorf("invalid command")
+}
+
+func rerunHistoryEntry(ctx context.Context, input *HandlerInput, indexArg string) error {
+	index, err := strconv.Atoi(indexArg)
+	if err != nil {
+		return fmt.Errorf("invalid history index '%s'", indexArg)
+	}
+	entry, ok := input.History.GetEntry(index)
+	if !ok {
+		return fmt.Errorf("there is no history entry with index %d", index)
+	}
+	if cmd, _ := getCmdAndArgs(entry.Query); cmd == constants.CmdHistory {
+		return fmt.Errorf("cannot re-run a %s command", constants.CmdHistory)
+	}
+	if input.RerunQuery == nil {
+		return fmt.Errorf("re-running queries is not supported")
+	}
+	// echo the query we are running
+	fmt.Println(entry.Query)
+	return input.RerunQuery(ctx, entry.Query)
+}
+
+// build a history filter from the args of '.history search'
+// args of the form since=<time> and status=<ok|error> are treated as filters - all other args are search text
+func parseHistoryFilter(args []string, now time.Time) (*queryhistory.HistoryFilter, error) {
+	filter := &queryhistory.HistoryFilter{}
+	var text []string
+	for _, arg := range args {
+		key, value, isFilter := parseHistoryFilterArg(arg)
+		if !isFilter {
+			text = append(text, arg)
+			continue
+		}
+		switch key {
+		case "since":
+			since, err := queryhistory.ParseSince(value, now)
+			if err != nil {
+				return nil, err
+			}
+			filter.Since = since
+		case "status":
+			value = strings.ToLower(value)
+			if value != queryhistory.StatusOk && value != queryhistory.StatusError {
+				return nil, fmt.Errorf("invalid status '%s' - expected %s or %s", value, queryhistory.StatusOk, queryhistory.StatusError)
+			}
+			filter.Status = value
+		}
+	}
+	filter.Text = strings.Join(text, " ")
+	return filter, nil
+}
+
+func parseHistoryFilterArg(arg string) (key, value string, isFilter bool) {
+	parts := strings.SplitN(arg, "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	key = strings.ToLower(parts[0])
+	if key != "since" && key != "status" {
+		return "", "", false
+	}
+	return key, parts[1], true
+}
+
+func displayHistory(history *queryhistory.QueryHistory, indices []int) {
+	header := []string{"#", "time", "duration", "rows", "status", "search_path", "query"}
+	rows := [][]string{}
+	for _, index := range indices {
+		entry, _ := history.GetEntry(index)
+		rows = append(rows, historyEntryRow(index, entry))
+	}
+	display.ShowWrappedTable(header, rows, false)
+}
+
+func historyEntryRow(index int, entry *queryhistory.HistoryEntry) []string {
+	var timestamp, duration, rowCount, status string
+	// entries loaded from a legacy history file have no metadata
+	if !entry.Timestamp.IsZero() {
+		timestamp = entry.Timestamp.Format("2006-01-02 15:04:05")
+		status = queryhistory.StatusOk
+		if entry.Failed() {
+			status = queryhistory.StatusError
+		}
+	}
+	if entry.Duration > 0 {
+		duration = entry.Duration.Round(time.Millisecond).String()
+		rowCount = strconv.Itoa(entry.RowCount)
+	}
+	return []string{
+		strconv.Itoa(index),
+		timestamp,
+		duration,
+		rowCount,
+		status,
+		strings.Join(entry.SearchPath, ","),
+		entry.Query,
+	}
+}
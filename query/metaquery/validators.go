@@ -131,6 +131,60 @@ var exactlyNArgs = func(n int) validator {
 
 var noArgs = exactlyNArgs(0)
 
+// validate the args of the .history metaquery, which supports:
+// .history
+// .history search <text> [since=<time>] [status=ok|error]
+// .history run <index>
+func historyValidator(args []string) ValidationResult {
+	if len(args) == 0 {
+		return ValidationResult{ShouldRun: true}
+	}
+	switch strings.ToLower(args[0]) {
+	case constants.ArgSearch:
+		return atLeastNArgs(2)(args)
+	case constants.ArgRun:
+		return exactlyNArgs(2)(args)
+	}
+	return ValidationResult{
+		Err: fmt.Errorf("valid values for this command are %v - got %s", []string{constants.ArgSearch, constants.ArgRun}, args[0]),
+	}
+}
+
+// validate the args of the .output metaquery, which supports:
+// .output <format>
+// .output sql <table>
+func outputValidator(args []string) ValidationResult {
+	if len(args) == 2 && strings.ToLower(args[0]) == constants.OutputFormatSQL {
+		return ValidationResult{ShouldRun: true}
+	}
+	return composeValidator(exactlyNArgs(1), validatorFromArgsOf(constants.CmdOutput))(args)
+}
+
+// validate the args of the .diff metaquery, which supports:
+// .diff
+// .diff off
+// .diff <file> [key columns]
+func diffValidator(args []string) ValidationResult {
+	if len(args) == 0 {
+		previousResultFile := cmdconfig.Viper().GetString(constants.ArgDiffAgainst)
+		if previousResultFile == "" {
+			return ValidationResult{
+				Message: fmt.Sprintf("diff mode is off. You can enable it with: %s",
+					constants.Bold(fmt.Sprintf("%s <file> [key columns]", constants.CmdDiff))),
+			}
+		}
+		message := fmt.Sprintf("diffing results against %s", constants.Bold(previousResultFile))
+		if keyColumns := cmdconfig.Viper().GetStringSlice(constants.ArgDiffKey); len(keyColumns) > 0 {
+			message = fmt.Sprintf("%s, keyed on %s", message, constants.Bold(strings.Join(keyColumns, ",")))
+		}
+		return ValidationResult{Message: message}
+	}
+	if strings.ToLower(args[0]) == constants.ArgOff {
+		return exactlyNArgs(1)(args)
+	}
+	return atMostNArgs(2)(args)
+}
+
 var allowedArgValues = func(caseSensitive bool, allowedValues ...string) validator {
 	return func(args []string) ValidationResult {
 		if !caseSensitive {
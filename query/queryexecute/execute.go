@@ -11,6 +11,7 @@ import (
 	"github.com/turbot/steampipe/display"
 	"github.com/turbot/steampipe/interactive"
 	"github.com/turbot/steampipe/query"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/utils"
 )
 
@@ -48,23 +49,27 @@ func RunBatchSession(ctx context.Context, initData *query.InitData) int {
 	// display any initialisation messages/warnings
 	initData.Result.DisplayMessages()
 
+	// resolve the export targets for each query
+	exportTargets, err := getExportTargets(initData.Queries)
+	utils.FailOnError(err)
+
 	failures := 0
 	if len(initData.Queries) > 0 {
 		// if we have resolved any queries, run them
-		failures = executeQueries(ctx, initData.Queries, initData.Client)
+		failures = executeQueries(ctx, initData.Queries, exportTargets, initData.Client)
 	}
 	// set global exit code
 	return failures
 }
 
-func executeQueries(ctx context.Context, queries []string, client db_common.Client) int {
+func executeQueries(ctx context.Context, queries []*modconfig.ResolvedQuery, exportTargets [][]exportTarget, client db_common.Client) int {
 	utils.LogTime("queryexecute.executeQueries start")
 	defer utils.LogTime("queryexecute.executeQueries end")
 
 	// run all queries
 	failures := 0
 	for i, q := range queries {
-		if err := executeQuery(ctx, q, client); err != nil {
+		if err := executeQuery(ctx, q.SQL, exportTargets[i], client); err != nil {
 			failures++
 			utils.ShowWarning(fmt.Sprintf("executeQueries: query %d of %d failed: %v", i+1, len(queries), err))
 		}
@@ -77,7 +82,7 @@ func executeQueries(ctx context.Context, queries []string, client db_common.Clie
 	return failures
 }
 
-func executeQuery(ctx context.Context, queryString string, client db_common.Client) error {
+func executeQuery(ctx context.Context, queryString string, exportTargets []exportTarget, client db_common.Client) error {
 	utils.LogTime("query.execute.executeQuery start")
 	defer utils.LogTime("query.execute.executeQuery end")
 
@@ -87,16 +92,23 @@ func executeQuery(ctx context.Context, queryString string, client db_common.Clie
 		return err
 	}
 
-	// print the data as it comes
+	// print (and export) the data as it comes
+	var exportErr error
 	for r := range resultsStreamer.Results {
-		display.ShowOutput(ctx, r)
+		if err := showAndExportResult(ctx, r, exportTargets); err != nil {
+			exportErr = err
+		}
 		// signal to the resultStreamer that we are done with this result
 		resultsStreamer.AllResultsRead()
 	}
-	return nil
+	return exportErr
 }
 
-// if we are displaying csv with no header, do not include lines between the query results
+// if we are displaying csv with no header, or a binary format, do not include lines between the query results
 func showBlankLineBetweenResults() bool {
-	return !(viper.GetString(constants.ArgOutput) == "csv" && !viper.GetBool(constants.ArgHeader))
+	output := viper.GetString(constants.ArgOutput)
+	if output == constants.OutputFormatParquet || output == constants.OutputFormatArrow {
+		return false
+	}
+	return !(output == "csv" && !viper.GetBool(constants.ArgHeader))
 }
@@ -0,0 +1,119 @@
+package queryexecute
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+type exportTarget struct {
+	format string
+	file   string
+}
+
+// characters which are replaced when building a file name from a query name
+var invalidFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// getExportTargets resolves the export args into the export targets for each query
+// if there are multiple queries, each query is exported to its own file
+func getExportTargets(queries []*modconfig.ResolvedQuery) ([][]exportTarget, error) {
+	targets := make([][]exportTarget, len(queries))
+	var targetErrors []error
+
+	now := time.Now()
+	timeFormatted := fmt.Sprintf("%d%02d%02d-%02d%02d%02d", now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second())
+
+	for _, export := range viper.GetStringSlice(constants.ArgExport) {
+		export = strings.TrimSpace(export)
+		if len(export) == 0 {
+			// if this is an empty string, ignore
+			continue
+		}
+
+		format, fileName, err := display.ResolveExportFormat(export)
+		if err != nil {
+			targetErrors = append(targetErrors, err)
+			continue
+		}
+
+		for idx, query := range queries {
+			queryName := getExportQueryName(query, idx, len(queries))
+			var targetFile string
+			switch {
+			case len(fileName) == 0:
+				targetFile = fmt.Sprintf("%s-%s%s", queryName, timeFormatted, display.ExportFileExtension(format))
+			case len(queries) > 1:
+				extension := filepath.Ext(fileName)
+				targetFile = fmt.Sprintf("%s_%s%s", strings.TrimSuffix(fileName, extension), queryName, extension)
+			default:
+				targetFile = fileName
+			}
+
+			if !exportTargetExists(targets[idx], targetFile) {
+				targets[idx] = append(targets[idx], exportTarget{format: format, file: targetFile})
+			}
+		}
+	}
+	return targets, utils.CombineErrors(targetErrors...)
+}
+
+// get the name used to identify a query in its export file name
+func getExportQueryName(query *modconfig.ResolvedQuery, idx, queryCount int) string {
+	if query.Name != "" {
+		return invalidFileNameChars.ReplaceAllString(query.Name, "_")
+	}
+	if queryCount > 1 {
+		return fmt.Sprintf("query_%d", idx+1)
+	}
+	return "query"
+}
+
+func exportTargetExists(targets []exportTarget, file string) bool {
+	for _, t := range targets {
+		if t.file == file {
+			return true
+		}
+	}
+	return false
+}
+
+// display the result and concurrently export it to each of the targets
+func showAndExportResult(ctx context.Context, result *queryresult.Result, targets []exportTarget) error {
+	if len(targets) == 0 {
+		display.ShowOutput(ctx, result)
+		return nil
+	}
+
+	results := queryresult.TeeResult(result, len(targets)+1)
+
+	var exportErrors []error
+	var exportErrorsLock sync.Mutex
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(exportResult *queryresult.Result, target exportTarget) {
+			defer wg.Done()
+			if err := display.ExportResult(exportResult, target.format, target.file); err != nil {
+				exportErrorsLock.Lock()
+				exportErrors = append(exportErrors, fmt.Errorf("failed to export to '%s': %v", target.file, err))
+				exportErrorsLock.Unlock()
+			}
+		}(results[i+1], target)
+	}
+
+	display.ShowOutput(ctx, results[0])
+	wg.Wait()
+
+	return utils.CombineErrors(exportErrors...)
+}
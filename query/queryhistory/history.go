@@ -12,7 +12,7 @@ import (
 
 // QueryHistory :: struct for working with history in the interactive mode
 type QueryHistory struct {
-	history []string
+	history []*HistoryEntry
 }
 
 // New creates a new QueryHistory object
@@ -23,15 +23,19 @@ func New() *QueryHistory {
 }
 
 // Push adds a string to the history queue trimming to maxHistorySize if necessary
-func (q *QueryHistory) Push(query string) {
+// it returns the history entry for the query, so the caller can populate the execution metadata
+// (nil is returned for blank queries)
+func (q *QueryHistory) Push(query string) *HistoryEntry {
 	if len(strings.TrimSpace(query)) == 0 {
 		// do not store a blank query
-		return
+		return nil
 	}
 
 	// do a strict compare to see if we have this same exact query as the most recent history item
-	if lastElement := q.Peek(); lastElement != nil && (*lastElement) == query {
-		return
+	// if so, reset and return the existing entry
+	if lastElement := q.Peek(); lastElement != nil && lastElement.Query == query {
+		lastElement.reset()
+		return lastElement
 	}
 
 	// limit the history length to HistorySize
@@ -41,16 +45,18 @@ func (q *QueryHistory) Push(query string) {
 	}
 
 	// append the new entry
-	q.history = append(q.history, query)
+	entry := NewHistoryEntry(query)
+	q.history = append(q.history, entry)
+	return entry
 }
 
 // Peek returns the last element of the history stack.
 // returns nil if there is no history
-func (q *QueryHistory) Peek() *string {
+func (q *QueryHistory) Peek() *HistoryEntry {
 	if len(q.history) == 0 {
 		return nil
 	}
-	return &q.history[len(q.history)-1]
+	return q.history[len(q.history)-1]
 }
 
 // Persist writes the history to the filesystem
@@ -74,21 +80,62 @@ func (q *QueryHistory) Persist() error {
 	return jsonEncoder.Encode(q.history)
 }
 
-// Get returns the full history
+// Get returns the full history as a list of query strings
 func (q *QueryHistory) Get() []string {
+	res := make([]string, len(q.history))
+	for i, entry := range q.history {
+		res[i] = entry.Query
+	}
+	return res
+}
+
+// Entries returns the full history, including the execution metadata
+func (q *QueryHistory) Entries() []*HistoryEntry {
 	return q.history
 }
 
+// GetEntry returns the history entry with the given (1-based) index
+func (q *QueryHistory) GetEntry(index int) (*HistoryEntry, bool) {
+	if index < 1 || index > len(q.history) {
+		return nil, false
+	}
+	return q.history[index-1], true
+}
+
+// Search returns the (1-based) indices of all history entries which satisfy the filter
+func (q *QueryHistory) Search(filter *HistoryFilter) []int {
+	var res []int
+	for i, entry := range q.history {
+		if filter.Matches(entry) {
+			res = append(res, i+1)
+		}
+	}
+	return res
+}
+
 // loads up the history from the file where it is persisted
 func (q *QueryHistory) load() error {
+	q.history = []*HistoryEntry{}
+
 	path := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
-	file, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		q.history = []string{}
 		return err
 	}
-	defer file.Close()
 
-	decoder := json.NewDecoder(file)
-	return decoder.Decode(&q.history)
+	var history []*HistoryEntry
+	if err := json.Unmarshal(data, &history); err == nil {
+		q.history = history
+		return nil
+	}
+
+	// the history may have been written by an older version of steampipe, which persisted a flat list of queries
+	var legacyHistory []string
+	if err := json.Unmarshal(data, &legacyHistory); err != nil {
+		return err
+	}
+	for _, query := range legacyHistory {
+		q.history = append(q.history, &HistoryEntry{Query: query})
+	}
+	return nil
 }
@@ -0,0 +1,46 @@
+package queryhistory
+
+import (
+	"time"
+)
+
+// HistoryEntry is a single query in the history, along with the metadata of its most recent execution
+type HistoryEntry struct {
+	Query      string        `json:"query"`
+	Timestamp  time.Time     `json:"timestamp"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	RowCount   int           `json:"row_count"`
+	Error      string        `json:"error,omitempty"`
+	SearchPath []string      `json:"search_path,omitempty"`
+	Workspace  string        `json:"workspace,omitempty"`
+}
+
+func NewHistoryEntry(query string) *HistoryEntry {
+	return &HistoryEntry{
+		Query:     query,
+		Timestamp: time.Now(),
+	}
+}
+
+// SetError records the error returned by the execution of the query
+func (e *HistoryEntry) SetError(err error) {
+	if e == nil || err == nil {
+		return
+	}
+	e.Error = err.Error()
+}
+
+// Failed returns whether the most recent execution of the query returned an error
+func (e *HistoryEntry) Failed() bool {
+	return e.Error != ""
+}
+
+// clear the execution metadata - this is called when the same query is pushed again
+func (e *HistoryEntry) reset() {
+	e.Timestamp = time.Now()
+	e.Duration = 0
+	e.RowCount = 0
+	e.Error = ""
+	e.SearchPath = nil
+	e.Workspace = ""
+}
@@ -0,0 +1,75 @@
+package queryhistory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StatusOk    = "ok"
+	StatusError = "error"
+)
+
+// HistoryFilter defines the criteria used to search the query history
+type HistoryFilter struct {
+	// case insensitive text which must appear in the query, search path or workspace
+	Text string
+	// only include entries executed at or after this time
+	Since time.Time
+	// only include entries with this status (ok or error)
+	Status string
+}
+
+// Matches returns whether the history entry satisfies the filter
+func (f *HistoryFilter) Matches(entry *HistoryEntry) bool {
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	switch f.Status {
+	case StatusOk:
+		if entry.Failed() {
+			return false
+		}
+	case StatusError:
+		if !entry.Failed() {
+			return false
+		}
+	}
+	if f.Text == "" {
+		return true
+	}
+
+	text := strings.ToLower(f.Text)
+	if strings.Contains(strings.ToLower(entry.Query), text) || strings.Contains(strings.ToLower(entry.Workspace), text) {
+		return true
+	}
+	for _, schema := range entry.SearchPath {
+		if strings.Contains(strings.ToLower(schema), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSince converts a 'since' filter value into a time
+// the value may be a date (2006-01-02), a timestamp (RFC3339),
+// a duration (e.g. 36h), or a number of days (e.g. 7d)
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid 'since' value '%s' - expected a date (YYYY-MM-DD), a timestamp, or a duration such as 24h or 7d", value)
+}
@@ -0,0 +1,91 @@
+package queryhistory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryFilterMatches(t *testing.T) {
+	now := time.Date(2022, 3, 10, 12, 0, 0, 0, time.UTC)
+	entry := &HistoryEntry{
+		Query:      "select * from aws_s3_bucket",
+		Timestamp:  now.Add(-48 * time.Hour),
+		SearchPath: []string{"public", "aws_prod"},
+		Workspace:  "/home/user/mods/inventory",
+	}
+	failedEntry := &HistoryEntry{
+		Query:     "select * from missing_table",
+		Timestamp: now.Add(-1 * time.Hour),
+		Error:     "relation \"missing_table\" does not exist",
+	}
+
+	type filterTest struct {
+		filter   HistoryFilter
+		entry    *HistoryEntry
+		expected bool
+	}
+	cases := map[string]filterTest{
+		"empty filter":          {HistoryFilter{}, entry, true},
+		"query text":            {HistoryFilter{Text: "S3_BUCKET"}, entry, true},
+		"search path text":      {HistoryFilter{Text: "aws_prod"}, entry, true},
+		"workspace text":        {HistoryFilter{Text: "inventory"}, entry, true},
+		"no text match":         {HistoryFilter{Text: "gcp"}, entry, false},
+		"since before":          {HistoryFilter{Since: now.Add(-72 * time.Hour)}, entry, true},
+		"since after":           {HistoryFilter{Since: now.Add(-24 * time.Hour)}, entry, false},
+		"status ok":             {HistoryFilter{Status: StatusOk}, entry, true},
+		"status error":          {HistoryFilter{Status: StatusError}, entry, false},
+		"status error failed":   {HistoryFilter{Status: StatusError}, failedEntry, true},
+		"status ok failed":      {HistoryFilter{Status: StatusOk}, failedEntry, false},
+		"text and since failed": {HistoryFilter{Text: "missing", Since: now.Add(-2 * time.Hour)}, failedEntry, true},
+	}
+
+	for name, test := range cases {
+		if actual := test.filter.Matches(test.entry); actual != test.expected {
+			t.Errorf("Test: '%s' FAILED: expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2022, 3, 10, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"2022-03-08":           time.Date(2022, 3, 8, 0, 0, 0, 0, time.UTC),
+		"2022-03-08T09:30:00Z": time.Date(2022, 3, 8, 9, 30, 0, 0, time.UTC),
+		"36h":                  now.Add(-36 * time.Hour),
+		"7d":                   now.AddDate(0, 0, -7),
+		"invalid":              {},
+	}
+
+	for value, expected := range cases {
+		actual, err := ParseSince(value, now)
+		if expected.IsZero() {
+			if err == nil {
+				t.Errorf("Test: '%s' FAILED: expected error but did not get one", value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s' FAILED with unexpected error: %v", value, err)
+			continue
+		}
+		if !actual.Equal(expected) {
+			t.Errorf("Test: '%s' FAILED: expected %v, got %v", value, expected, actual)
+		}
+	}
+}
+
+func TestPush(t *testing.T) {
+	history := &QueryHistory{}
+	if entry := history.Push("  "); entry != nil {
+		t.Errorf("expected blank query not to be stored")
+	}
+	first := history.Push("select 1")
+	first.RowCount = 1
+	if again := history.Push("select 1"); again != first || again.RowCount != 0 {
+		t.Errorf("expected repeated query to reset and return the existing entry")
+	}
+	history.Push("select 2")
+	if actual := history.Get(); len(actual) != 2 || actual[0] != "select 1" || actual[1] != "select 2" {
+		t.Errorf("unexpected history %v", actual)
+	}
+}
@@ -0,0 +1,40 @@
+package queryresult
+
+// TeeResult splits the result into 'count' results, each of which receives every row of the source result
+// rows are streamed to each result in turn, so all results must be read concurrently
+func TeeResult(result *Result, count int) []*Result {
+	results := make([]*Result, count)
+	for i := range results {
+		results[i] = NewQueryResult(result.ColTypes)
+	}
+
+	go func() {
+		streaming := true
+		for row := range *result.RowChan {
+			// once an error has been streamed the readers stop reading rows
+			// - continue to drain the source result so the session is released, but do not forward
+			if !streaming {
+				continue
+			}
+			if row.Error != nil {
+				streaming = false
+			}
+			for _, r := range results {
+				*r.RowChan <- row
+			}
+		}
+		// the duration is sent before the row channel is closed (it is not sent at all if reading the rows failed)
+		select {
+		case duration := <-result.Duration:
+			for _, r := range results {
+				r.Duration <- duration
+			}
+		default:
+		}
+		for _, r := range results {
+			r.Close()
+		}
+	}()
+
+	return results
+}
@@ -248,6 +248,13 @@ func runPluginManagerInProcess() (*pluginmanager.PluginManager, error) {
 			PluginShortName: v.PluginShortName,
 			Config:          v.Config,
 		}
+		pluginOptions := steampipeConfig.GetPluginOptions(k)
+		if pluginOptions.MemoryMaxMb != nil {
+			configMap[k].MemoryMaxMb = int64(*pluginOptions.MemoryMaxMb)
+		}
+		if pluginOptions.IdleTimeout != nil {
+			configMap[k].IdleTimeout = int64(*pluginOptions.IdleTimeout)
+		}
 	}
 	return pluginmanager.NewPluginManager(configMap, logger), nil
 }
@@ -89,6 +89,7 @@ func loadSteampipeConfig(workspacePath string, commandName string) (steampipeCon
 
 	// now set default options on all connections without options set
 	steampipeConfig.setDefaultConnectionOptions()
+	steampipeConfig.setDefaultPluginOptions()
 
 	// now validate the config
 	if err := steampipeConfig.Validate(); err != nil {
@@ -159,6 +160,28 @@ func loadConfig(configFolder string, steampipeConfig *SteampipeConfig, opts *loa
 			}
 			steampipeConfig.Connections[connection.Name] = connection
 
+		case "schedule":
+			schedule, moreDiags := parse.DecodeSchedule(block)
+			if moreDiags.HasErrors() {
+				diags = append(diags, moreDiags...)
+				continue
+			}
+			if _, alreadyThere := steampipeConfig.Schedules[schedule.Name]; alreadyThere {
+				return fmt.Errorf("duplicate schedule name: '%s' in '%s'", schedule.Name, block.TypeRange.Filename)
+			}
+			steampipeConfig.Schedules[schedule.Name] = schedule
+
+		case "webhook":
+			webhook, moreDiags := parse.DecodeWebhook(block)
+			if moreDiags.HasErrors() {
+				diags = append(diags, moreDiags...)
+				continue
+			}
+			if _, alreadyThere := steampipeConfig.Webhooks[webhook.Name]; alreadyThere {
+				return fmt.Errorf("duplicate webhook name: '%s' in '%s'", webhook.Name, block.TypeRange.Filename)
+			}
+			steampipeConfig.Webhooks[webhook.Name] = webhook
+
 		case "options":
 			// check this options type is permitted based on the options passed in
 			if err := optionsBlockPermitted(block, optionBlockMap, opts); err != nil {
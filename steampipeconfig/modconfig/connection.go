@@ -40,8 +40,10 @@ type Connection struct {
 	Config string `json:"Config,omitempty"`
 
 	// options
-	Options   *options.Connection `json:"Options,omitempty"`
-	DeclRange hcl.Range
+	Options *options.Connection `json:"Options,omitempty"`
+	// plugin process options - these override the default plugin options
+	PluginOptions *options.Plugin `json:"PluginOptions,omitempty"`
+	DeclRange     hcl.Range
 }
 
 func NewConnection(block *hcl.Block) *Connection {
@@ -62,16 +64,18 @@ func (c *Connection) Equals(other *Connection) bool {
 }
 
 // SetOptions sets the options on the connection
-// verify the options object is a valid options type (only options.Connection currently supported)
+// verify the options object is a valid options type (only options.Connection and options.Plugin currently supported)
 func (c *Connection) SetOptions(opts options.Options, block *hcl.Block) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	switch o := opts.(type) {
 	case *options.Connection:
 		c.Options = o
+	case *options.Plugin:
+		c.PluginOptions = o
 	default:
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
-			Summary:  fmt.Sprintf("invalid nested option type %s - only 'connection' options blocks are supported for Connections", reflect.TypeOf(o).Name()),
+			Summary:  fmt.Sprintf("invalid nested option type %s - only 'connection' and 'plugin' options blocks are supported for Connections", reflect.TypeOf(o).Name()),
 			Subject:  &block.DefRange,
 		})
 	}
@@ -0,0 +1,9 @@
+package modconfig
+
+// ResolvedQuery contains the SQL resolved from a query command line argument,
+// along with the name of the query, control or file it was resolved from
+type ResolvedQuery struct {
+	// Name is empty if the argument was raw SQL
+	Name string
+	SQL  string
+}
@@ -10,12 +10,38 @@ type RuntimeDependency struct {
 	SourceResource   HclResource
 	TargetProperties []string
 	Value            *string
+	// if this dependency populates an arg, either the name or the index of the arg
+	ArgName  *string
+	ArgIndex *int
 }
 
 func (d *RuntimeDependency) String() string {
 	return fmt.Sprintf("%s->%s", strings.Join(d.TargetProperties, ","), d.PropertyPath.String())
 }
 
+// IsArgDependency returns whether this dependency populates one of the args of its resource
+func (d *RuntimeDependency) IsArgDependency() bool {
+	return d.ArgName != nil || d.ArgIndex != nil
+}
+
+// SetArg sets the arg populated by this dependency to the given (postgres formatted) value
+func (d *RuntimeDependency) SetArg(args *QueryArgs, value string) {
+	if d.ArgName != nil {
+		if args.Args == nil {
+			args.Args = make(map[string]string)
+		}
+		args.Args[*d.ArgName] = value
+		return
+	}
+	if d.ArgIndex != nil {
+		// extend the args list if necessary
+		for len(args.ArgsList) <= *d.ArgIndex {
+			args.ArgsList = append(args.ArgsList, "")
+		}
+		args.ArgsList[*d.ArgIndex] = value
+	}
+}
+
 func (d *RuntimeDependency) ResolveSource(resource HclResource, dashboard *Dashboard, workspace ResourceMapsProvider) error {
 	// TODO THINK ABOUT REPORT PREFIX
 
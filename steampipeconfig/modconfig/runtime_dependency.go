@@ -4,8 +4,10 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe/steampipeconfig/hclhelpers"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type ResourceDependency struct {
@@ -57,6 +59,10 @@ func (d *ResourceDependency) ToRuntimeDependency(bodyContent *hcl.BodyContent) *
 	if len(res.TargetProperties) == 0 {
 		return nil
 	}
+	// if this dependency populates an arg, determine which one
+	if argsAttr, ok := bodyContent.Attributes["args"]; ok && helpers.StringSliceContains(targetProperties, "args") {
+		res.ArgName, res.ArgIndex = d.getArgPosition(argsAttr.Expr)
+	}
 	return res
 }
 
@@ -98,3 +104,38 @@ func (d *ResourceDependency) getPropertiesFromContent(content *hcl.BodyContent)
 	}
 	return res
 }
+
+// getArgPosition determines which arg of the given args expression is populated by this dependency
+// - for a map of args, return the arg name
+// - for a list of args, return the arg index
+func (d *ResourceDependency) getArgPosition(expr hcl.Expression) (*string, *int) {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			if !d.isDependencyExpression(item.ValueExpr) {
+				continue
+			}
+			// object keys may be either identifiers or strings - both evaluate to a string without context
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.Type() != cty.String {
+				return nil, nil
+			}
+			argName := key.AsString()
+			return &argName, nil
+		}
+	case *hclsyntax.TupleConsExpr:
+		for i, itemExpr := range e.Exprs {
+			if d.isDependencyExpression(itemExpr) {
+				argIndex := i
+				return nil, &argIndex
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isDependencyExpression returns whether the expression consists solely of this dependency
+func (d *ResourceDependency) isDependencyExpression(expr hcl.Expression) bool {
+	vars := expr.Variables()
+	return len(vars) == 1 && hclhelpers.TraversalAsString(vars[0]) == hclhelpers.TraversalAsString(d.Traversals[0])
+}
@@ -0,0 +1,67 @@
+package modconfig
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type runtimeDependencyArgsTest struct {
+	source   string
+	args     *QueryArgs
+	expected interface{}
+}
+
+var testCasesRuntimeDependencyArgs = map[string]runtimeDependencyArgsTest{
+	"named arg": {
+		source:   `args = { region = self.input.region.value }`,
+		args:     NewQueryArgs(),
+		expected: &QueryArgs{Args: map[string]string{"region": "'us-east-1'"}},
+	},
+	"named arg with quoted key": {
+		source:   `args = { "region" = self.input.region.value }`,
+		args:     NewQueryArgs(),
+		expected: &QueryArgs{Args: map[string]string{"region": "'us-east-1'"}},
+	},
+	"positional arg": {
+		source:   `args = [ self.input.region.value ]`,
+		args:     &QueryArgs{},
+		expected: &QueryArgs{ArgsList: []string{"'us-east-1'"}},
+	},
+	"not an arg": {
+		source:   `title = self.input.region.value`,
+		expected: "NOT_ARG",
+	},
+}
+
+func TestRuntimeDependencySetArg(t *testing.T) {
+	for name, test := range testCasesRuntimeDependencyArgs {
+		file, diags := hclsyntax.ParseConfig([]byte(test.source), "test.sp", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			t.Fatalf("Test: '%s'' FAILED : \nfailed to parse source: %s", name, diags.Error())
+		}
+		content, _ := file.Body.Content(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "args"}, {Name: "title"}}})
+		var traversals []hcl.Traversal
+		for _, attr := range content.Attributes {
+			traversals = append(traversals, attr.Expr.Variables()...)
+		}
+
+		dependency := (&ResourceDependency{Traversals: traversals}).ToRuntimeDependency(content)
+		if dependency == nil {
+			t.Errorf("Test: '%s'' FAILED : \nexpected a runtime dependency", name)
+			continue
+		}
+		if test.expected == "NOT_ARG" {
+			if dependency.IsArgDependency() {
+				t.Errorf("Test: '%s'' FAILED : \nexpected dependency not to populate an arg", name)
+			}
+			continue
+		}
+
+		dependency.SetArg(test.args, "'us-east-1'")
+		if !test.args.Equals(test.expected.(*QueryArgs)) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expected, test.args)
+		}
+	}
+}
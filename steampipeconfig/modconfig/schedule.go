@@ -0,0 +1,31 @@
+package modconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Schedule is a struct representing a schedule block in the steampipe config
+// the targets (dashboards and/or benchmarks) are executed by the dashboard server whenever the cron expression fires
+type Schedule struct {
+	// schedule name
+	Name string
+	// standard 5 field cron expression, e.g. "0 * * * *"
+	Cron string
+	// the names of the dashboards and benchmarks to execute
+	Targets   []string
+	DeclRange hcl.Range
+}
+
+func NewSchedule(block *hcl.Block) *Schedule {
+	return &Schedule{
+		Name:      block.Labels[0],
+		DeclRange: block.TypeRange,
+	}
+}
+
+func (s *Schedule) String() string {
+	return fmt.Sprintf("\n----\nName: %s\nCron: %s\nTargets: %s\n", s.Name, s.Cron, strings.Join(s.Targets, ", "))
+}
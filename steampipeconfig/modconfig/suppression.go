@@ -0,0 +1,57 @@
+package modconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Suppression is a struct representing a suppression block
+// - this marks the failing results of a control for a specific resource (and optionally dimension values)
+// as an accepted risk, until the expiry date
+type Suppression struct {
+	Name string
+	// the name of the control - this may be the short or fully qualified name
+	Control  string
+	Resource string
+	// optional map of dimension key to value - if set, all must match for a result to be suppressed
+	Dimensions    map[string]string
+	Justification string
+	Expires       time.Time
+
+	DeclRange hcl.Range
+}
+
+func NewSuppression(block *hcl.Block) *Suppression {
+	return &Suppression{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+}
+
+// Expired returns whether the suppression has expired at the given time
+func (s *Suppression) Expired(now time.Time) bool {
+	return !now.Before(s.Expires)
+}
+
+// Matches returns whether the suppression applies to the result for the given control and resource
+// getDimensionValue is used to retrieve the dimension values of the result
+func (s *Suppression) Matches(control *Control, resource string, getDimensionValue func(string) string) bool {
+	if s.Control != control.Name() && s.Control != control.UnqualifiedName {
+		return false
+	}
+	if s.Resource != resource {
+		return false
+	}
+	for key, value := range s.Dimensions {
+		if getDimensionValue(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Suppression) String() string {
+	return fmt.Sprintf("suppression '%s' (%s:%d)", s.Name, s.DeclRange.Filename, s.DeclRange.Start.Line)
+}
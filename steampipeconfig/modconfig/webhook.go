@@ -0,0 +1,34 @@
+package modconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Webhook is a struct representing a webhook block in the steampipe config
+// when a dashboard or check event occurs, the event is POSTed to the url
+type Webhook struct {
+	// webhook name
+	Name string
+	Url  string
+	// the events which are sent - if empty, all events are sent
+	Events []string
+	// additional request headers
+	Headers map[string]string
+	// optional go template for the request body - if not set, the event is sent as JSON
+	Body      *string
+	DeclRange hcl.Range
+}
+
+func NewWebhook(block *hcl.Block) *Webhook {
+	return &Webhook{
+		Name:      block.Labels[0],
+		DeclRange: block.TypeRange,
+	}
+}
+
+func (w *Webhook) String() string {
+	return fmt.Sprintf("\n----\nName: %s\nUrl: %s\nEvents: %s\n", w.Name, w.Url, strings.Join(w.Events, ", "))
+}
@@ -0,0 +1,152 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+// Dashboard
+type Dashboard struct {
+	Port   *int    `hcl:"port"`
+	Listen *string `hcl:"listen"`
+	// if TLS is enabled and no certificate is given, the self signed service certificate is used
+	TLS            *bool   `hcl:"tls"`
+	TLSCertificate *string `hcl:"tls_certificate"`
+	TLSKey         *string `hcl:"tls_key"`
+	// either (or both) token and basic auth may be set
+	AuthToken    *string `hcl:"auth_token"`
+	AuthUsername *string `hcl:"auth_username"`
+	AuthPassword *string `hcl:"auth_password"`
+	// the expiration (TTL) in seconds of cached leaf query results - if this is not set, results are not cached
+	CacheTTL *int `hcl:"cache_ttl"`
+	// the number of scheduled run results to retain - the results of older runs are deleted (0 retains all results)
+	ResultRetention *int `hcl:"result_retention"`
+}
+
+// ConfigMap :: create a config map to pass to viper
+func (d *Dashboard) ConfigMap() map[string]interface{} {
+	// only add keys which are non null
+	res := map[string]interface{}{}
+	if d.Port != nil {
+		res[constants.ArgDashboardServerPort] = d.Port
+	}
+	if d.Listen != nil {
+		res[constants.ArgDashboardServerListen] = d.Listen
+	}
+	if d.TLS != nil {
+		res[constants.ArgDashboardServerTLS] = d.TLS
+	}
+	if d.TLSCertificate != nil {
+		res[constants.ArgDashboardServerTLSCertificate] = d.TLSCertificate
+	}
+	if d.TLSKey != nil {
+		res[constants.ArgDashboardServerTLSKey] = d.TLSKey
+	}
+	if d.AuthToken != nil {
+		res[constants.ArgDashboardServerAuthToken] = d.AuthToken
+	}
+	if d.AuthUsername != nil {
+		res[constants.ArgDashboardServerAuthUsername] = d.AuthUsername
+	}
+	if d.AuthPassword != nil {
+		res[constants.ArgDashboardServerAuthPassword] = d.AuthPassword
+	}
+	if d.CacheTTL != nil {
+		res[constants.ArgDashboardServerCacheTTL] = d.CacheTTL
+	}
+	if d.ResultRetention != nil {
+		res[constants.ArgDashboardServerResultRetention] = d.ResultRetention
+	}
+	return res
+}
+
+// Merge ::  merge other options over the the top of this options object
+// i.e. if a property is set in otherOptions, it takes precedence
+func (d *Dashboard) Merge(otherOptions Options) {
+	switch o := otherOptions.(type) {
+	case *Dashboard:
+		if o.Port != nil {
+			d.Port = o.Port
+		}
+		if o.Listen != nil {
+			d.Listen = o.Listen
+		}
+		if o.TLS != nil {
+			d.TLS = o.TLS
+		}
+		if o.TLSCertificate != nil {
+			d.TLSCertificate = o.TLSCertificate
+		}
+		if o.TLSKey != nil {
+			d.TLSKey = o.TLSKey
+		}
+		if o.AuthToken != nil {
+			d.AuthToken = o.AuthToken
+		}
+		if o.AuthUsername != nil {
+			d.AuthUsername = o.AuthUsername
+		}
+		if o.AuthPassword != nil {
+			d.AuthPassword = o.AuthPassword
+		}
+		if o.CacheTTL != nil {
+			d.CacheTTL = o.CacheTTL
+		}
+		if o.ResultRetention != nil {
+			d.ResultRetention = o.ResultRetention
+		}
+	}
+}
+
+func (d *Dashboard) String() string {
+	if d == nil {
+		return ""
+	}
+	var str []string
+	if d.Port == nil {
+		str = append(str, "  Port: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  Port: %d", *d.Port))
+	}
+	if d.Listen == nil {
+		str = append(str, "  Listen: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  Listen: %s", *d.Listen))
+	}
+	if d.TLS == nil {
+		str = append(str, "  TLS: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLS: %v", *d.TLS))
+	}
+	if d.TLSCertificate == nil {
+		str = append(str, "  TLSCertificate: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLSCertificate: %s", *d.TLSCertificate))
+	}
+	if d.TLSKey == nil {
+		str = append(str, "  TLSKey: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLSKey: %s", *d.TLSKey))
+	}
+	// do not include credentials
+	str = append(str, fmt.Sprintf("  AuthToken set: %v", d.AuthToken != nil))
+	if d.AuthUsername == nil {
+		str = append(str, "  AuthUsername: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  AuthUsername: %s", *d.AuthUsername))
+	}
+	str = append(str, fmt.Sprintf("  AuthPassword set: %v", d.AuthPassword != nil))
+	if d.CacheTTL == nil {
+		str = append(str, "  CacheTTL: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  CacheTTL: %d", *d.CacheTTL))
+	}
+	if d.ResultRetention == nil {
+		str = append(str, "  ResultRetention: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  ResultRetention: %d", *d.ResultRetention))
+	}
+	return strings.Join(str, "\n")
+}
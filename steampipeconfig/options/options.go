@@ -3,8 +3,10 @@ package options
 // hcl options block types
 const (
 	ConnectionBlock = "connection"
+	DashboardBlock  = "dashboard"
 	DatabaseBlock   = "database"
 	GeneralBlock    = "general"
+	PluginBlock     = "plugin"
 	TerminalBlock   = "terminal"
 )
 
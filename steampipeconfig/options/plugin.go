@@ -0,0 +1,53 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plugin is a struct representing plugin process options
+// these are enforced by the plugin manager for the plugin process of each connection
+// json tags needed as this is stored in the connection state file
+type Plugin struct {
+	// the memory limit (in MB) of the plugin process - a plugin which exceeds this is restarted
+	MemoryMaxMb *int `hcl:"memory_max_mb" json:"MemoryMaxMb,omitempty"`
+	// the time (in seconds) after which an unused plugin process is stopped - zero disables this
+	IdleTimeout *int `hcl:"idle_timeout" json:"IdleTimeout,omitempty"`
+}
+
+func (p *Plugin) ConfigMap() map[string]interface{} {
+	// not implemented - we do not pass this config to viper
+	return map[string]interface{}{}
+}
+
+// Merge merges other options over the the top of this options object
+// i.e. if a property is set in otherOptions, it takes precedence
+func (p *Plugin) Merge(otherOptions Options) {
+	switch o := otherOptions.(type) {
+	case *Plugin:
+		if o.MemoryMaxMb != nil {
+			p.MemoryMaxMb = o.MemoryMaxMb
+		}
+		if o.IdleTimeout != nil {
+			p.IdleTimeout = o.IdleTimeout
+		}
+	}
+}
+
+func (p *Plugin) String() string {
+	if p == nil {
+		return ""
+	}
+	var str []string
+	if p.MemoryMaxMb == nil {
+		str = append(str, "  MemoryMaxMb: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  MemoryMaxMb: %d", *p.MemoryMaxMb))
+	}
+	if p.IdleTimeout == nil {
+		str = append(str, "  IdleTimeout: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  IdleTimeout: %d", *p.IdleTimeout))
+	}
+	return strings.Join(str, "\n")
+}
@@ -0,0 +1,86 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/turbot/steampipe-plugin-sdk/v3/plugin"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ParseCommandLineArgs parses query args passed on the command line in the form name=value
+// into a map of raw (unconverted) values
+func ParseCommandLineArgs(args []string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, arg := range args {
+		// split on the first '=' - the value may itself contain '='
+		parts := strings.SplitN(arg, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("invalid arg '%s' - args must be of the form name=value", arg)
+		}
+		res[name] = parts[1]
+	}
+	return res, nil
+}
+
+// ConvertCommandLineArg converts a raw command line arg value into a postgres representation,
+// verifying the value is compatible with the type of the param default (if there is one)
+// params with no default (or a string default) accept any value, which is passed as a string literal
+func ConvertCommandLineArg(value string, param *modconfig.ParamDef) (string, error) {
+	defaultValue, ok := param.RawDefault.(cty.Value)
+	if !ok || defaultValue.IsNull() {
+		return pgStringLiteral(value), nil
+	}
+
+	ty := defaultValue.Type()
+	switch {
+	case ty == cty.String:
+		return pgStringLiteral(value), nil
+	case ty == cty.Number:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return "", fmt.Errorf("invalid value for param '%s': expected a number, got '%s'", param.Name, value)
+		}
+		return strings.TrimSpace(value), nil
+	case ty == cty.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("invalid value for param '%s': expected a bool, got '%s'", param.Name, value)
+		}
+		return strconv.FormatBool(b), nil
+	case ty.IsTupleType(), ty.IsListType(), ty.IsSetType():
+		v, err := parseArgValue(value)
+		if err != nil || !(v.Type().IsTupleType() || v.Type().IsListType()) {
+			return "", fmt.Errorf("invalid value for param '%s': expected a list, e.g. [\"a\", \"b\"], got '%s'", param.Name, value)
+		}
+		return ctyToPostgresString(v)
+	case ty.IsObjectType(), ty.IsMapType():
+		v, err := parseArgValue(value)
+		if err != nil || !(v.Type().IsObjectType() || v.Type().IsMapType()) {
+			return "", fmt.Errorf("invalid value for param '%s': expected an object, e.g. {\"a\" = \"b\"}, got '%s'", param.Name, value)
+		}
+		return ctyToPostgresString(v)
+	}
+	return pgStringLiteral(value), nil
+}
+
+// parse a value as an HCL expression
+func parseArgValue(value string) (cty.Value, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(value), "", hcl.Pos{})
+	if diags.HasErrors() {
+		return cty.NilVal, plugin.DiagsToError("bad arg syntax", diags)
+	}
+	v, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, plugin.DiagsToError("bad arg syntax", diags)
+	}
+	return v, nil
+}
+
+func pgStringLiteral(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
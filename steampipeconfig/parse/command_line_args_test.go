@@ -0,0 +1,96 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type convertCommandLineArgTest struct {
+	value        string
+	defaultValue interface{}
+	expected     string
+}
+
+var testCasesConvertCommandLineArg = map[string]convertCommandLineArgTest{
+	"no default": {
+		value:    "us-east-1",
+		expected: "'us-east-1'",
+	},
+	"no default with quote": {
+		value:    "o'brien",
+		expected: "'o''brien'",
+	},
+	"string default": {
+		value:        "123",
+		defaultValue: cty.StringVal("foo"),
+		expected:     "'123'",
+	},
+	"number default": {
+		value:        " 42 ",
+		defaultValue: cty.NumberIntVal(1),
+		expected:     "42",
+	},
+	"number default invalid": {
+		value:        "forty two",
+		defaultValue: cty.NumberIntVal(1),
+		expected:     "ERROR",
+	},
+	"bool default": {
+		value:        "TRUE",
+		defaultValue: cty.False,
+		expected:     "true",
+	},
+	"bool default invalid": {
+		value:        "yes please",
+		defaultValue: cty.False,
+		expected:     "ERROR",
+	},
+	"list default": {
+		value:        `["a", "b"]`,
+		defaultValue: cty.TupleVal([]cty.Value{cty.StringVal("c")}),
+		expected:     "array['a','b']",
+	},
+	"list default invalid": {
+		value:        "a",
+		defaultValue: cty.TupleVal([]cty.Value{cty.StringVal("c")}),
+		expected:     "ERROR",
+	},
+}
+
+func TestConvertCommandLineArg(t *testing.T) {
+	for name, test := range testCasesConvertCommandLineArg {
+		param := &modconfig.ParamDef{Name: "p1", RawDefault: test.defaultValue}
+		res, err := ConvertCommandLineArg(test.value, param)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		if res != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected:\n%s\n\ngot:\n%s", name, test.expected, res)
+		}
+	}
+}
+
+func TestParseCommandLineArgs(t *testing.T) {
+	res, err := ParseCommandLineArgs([]string{"p1=foo", "p2=a=b", "p1=bar"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(res) != 2 || res["p1"] != "bar" || res["p2"] != "a=b" {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	for _, invalid := range []string{"p1", "=foo"} {
+		if _, err := ParseCommandLineArgs([]string{invalid}); err == nil {
+			t.Errorf("expected error parsing '%s'", invalid)
+		}
+	}
+}
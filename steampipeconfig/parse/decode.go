@@ -261,6 +261,8 @@ func decodeParam(block *hcl.Block, runCtx *RunContext, parentName string) (*modc
 		diags = append(diags, moreDiags...)
 
 		if !moreDiags.HasErrors() {
+			// store the raw default - it is used to type check args passed on the command line
+			def.RawDefault = v
 			// convert the raw default into a postgres representation
 			if valStr, err := ctyToPostgresString(v); err == nil {
 				def.Default = utils.ToStringPointer(valStr)
@@ -15,12 +15,16 @@ func DecodeOptions(block *hcl.Block) (options.Options, hcl.Diagnostics) {
 	switch block.Labels[0] {
 	case options.ConnectionBlock:
 		dest = &options.Connection{}
+	case options.DashboardBlock:
+		dest = &options.Dashboard{}
 	case options.DatabaseBlock:
 		dest = &options.Database{}
 	case options.TerminalBlock:
 		dest = &options.Terminal{}
 	case options.GeneralBlock:
 		dest = &options.General{}
+	case options.PluginBlock:
+		dest = &options.Plugin{}
 	default:
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -0,0 +1,62 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/robfig/cron"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func DecodeSchedule(block *hcl.Block) (*modconfig.Schedule, hcl.Diagnostics) {
+	scheduleContent, diags := block.Body.Content(ScheduleBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	schedule := modconfig.NewSchedule(block)
+
+	cronAttr := scheduleContent.Attributes["cron"]
+	diags = gohcl.DecodeExpression(cronAttr.Expr, nil, &schedule.Cron)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if _, err := cron.ParseStandard(schedule.Cron); err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("invalid cron expression '%s' for schedule '%s': %s", schedule.Cron, schedule.Name, err.Error()),
+			Subject:  &cronAttr.Range,
+		})
+		return nil, diags
+	}
+
+	targetsAttr := scheduleContent.Attributes["targets"]
+	diags = gohcl.DecodeExpression(targetsAttr.Expr, nil, &schedule.Targets)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if len(schedule.Targets) == 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("schedule '%s' has no targets", schedule.Name),
+			Subject:  &targetsAttr.Range,
+		})
+	}
+	// only dashboards and benchmarks may be scheduled
+	for _, target := range schedule.Targets {
+		parsedName, err := modconfig.ParseResourceName(target)
+		if err != nil || (parsedName.ItemType != modconfig.BlockTypeDashboard && parsedName.ItemType != modconfig.BlockTypeBenchmark) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("invalid target '%s' for schedule '%s' - only dashboards and benchmarks may be scheduled", target, schedule.Name),
+				Subject:  &targetsAttr.Range,
+			})
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return schedule, diags
+}
@@ -0,0 +1,79 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type decodeScheduleTest struct {
+	source   string
+	expected interface{}
+}
+
+var testCasesDecodeSchedule = map[string]decodeScheduleTest{
+	"dashboard and benchmark": {
+		source: `schedule "nightly" {
+  cron    = "0 2 * * *"
+  targets = ["dashboard.d1", "m1.benchmark.b1"]
+}`,
+		expected: []string{"dashboard.d1", "m1.benchmark.b1"},
+	},
+	"invalid cron": {
+		source: `schedule "nightly" {
+  cron    = "every night"
+  targets = ["dashboard.d1"]
+}`,
+		expected: "ERROR",
+	},
+	"query target": {
+		source: `schedule "nightly" {
+  cron    = "0 2 * * *"
+  targets = ["query.q1"]
+}`,
+		expected: "ERROR",
+	},
+	"no targets": {
+		source: `schedule "nightly" {
+  cron    = "0 2 * * *"
+  targets = []
+}`,
+		expected: "ERROR",
+	},
+	"missing cron": {
+		source: `schedule "nightly" {
+  targets = ["dashboard.d1"]
+}`,
+		expected: "ERROR",
+	},
+}
+
+func TestDecodeSchedule(t *testing.T) {
+	for name, test := range testCasesDecodeSchedule {
+		file, diags := hclsyntax.ParseConfig([]byte(test.source), "test.spc", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			t.Fatalf("Test: '%s'' FAILED : failed to parse source: %s", name, diags.Error())
+		}
+		content, diags := file.Body.Content(ConfigBlockSchema)
+		if diags.HasErrors() {
+			t.Fatalf("Test: '%s'' FAILED : failed to decode source: %s", name, diags.Error())
+		}
+
+		schedule, diags := DecodeSchedule(content.Blocks[0])
+		if diags.HasErrors() {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED with unexpected error: %s", name, diags.Error())
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		if schedule.Name != "nightly" || !reflect.DeepEqual(schedule.Targets, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected targets %v, got %v", name, test.expected, schedule.Targets)
+		}
+	}
+}
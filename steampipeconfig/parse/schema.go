@@ -16,6 +16,45 @@ var ConfigBlockSchema = &hcl.BodySchema{
 			Type:       "options",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type:       "schedule",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "webhook",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+var ScheduleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "cron",
+			Required: true,
+		},
+		{
+			Name:     "targets",
+			Required: true,
+		},
+	},
+}
+
+var WebhookBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "url",
+			Required: true,
+		},
+		{
+			Name: "events",
+		},
+		{
+			Name: "headers",
+		},
+		{
+			Name: "body",
+		},
 	},
 }
 
@@ -252,3 +291,36 @@ var VariableBlockSchema = &hcl.BodySchema{
 		},
 	},
 }
+
+var SuppressionsFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "suppression",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+var SuppressionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "control",
+			Required: true,
+		},
+		{
+			Name:     "resource",
+			Required: true,
+		},
+		{
+			Name: "dimensions",
+		},
+		{
+			Name:     "justification",
+			Required: true,
+		},
+		{
+			Name:     "expires",
+			Required: true,
+		},
+	},
+}
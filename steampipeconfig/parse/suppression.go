@@ -0,0 +1,116 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/turbot/steampipe-plugin-sdk/v3/plugin"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the supported formats for the suppression expiry
+var suppressionExpiryFormats = []string{"2006-01-02", time.RFC3339}
+
+// ParseSuppressions parses the suppression blocks in the given files
+func ParseSuppressions(paths ...string) ([]*modconfig.Suppression, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	fileData, diags := LoadFileData(paths...)
+	if diags.HasErrors() {
+		return nil, plugin.DiagsToError("Failed to load suppression files", diags)
+	}
+
+	body, diags := ParseHclFiles(fileData)
+	if diags.HasErrors() {
+		return nil, plugin.DiagsToError("Failed to load suppression files", diags)
+	}
+
+	content, diags := body.Content(SuppressionsFileSchema)
+	if diags.HasErrors() {
+		return nil, plugin.DiagsToError("Failed to load suppressions", diags)
+	}
+
+	var suppressions []*modconfig.Suppression
+	suppressionNames := make(map[string]bool)
+	for _, block := range content.Blocks {
+		suppression, moreDiags := DecodeSuppression(block)
+		if moreDiags.HasErrors() {
+			diags = append(diags, moreDiags...)
+			continue
+		}
+		if suppressionNames[suppression.Name] {
+			return nil, fmt.Errorf("duplicate suppression name: '%s' in '%s'", suppression.Name, block.TypeRange.Filename)
+		}
+		suppressionNames[suppression.Name] = true
+		suppressions = append(suppressions, suppression)
+	}
+	if diags.HasErrors() {
+		return nil, plugin.DiagsToError("Failed to load suppressions", diags)
+	}
+	return suppressions, nil
+}
+
+func DecodeSuppression(block *hcl.Block) (*modconfig.Suppression, hcl.Diagnostics) {
+	content, diags := block.Body.Content(SuppressionBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	suppression := modconfig.NewSuppression(block)
+
+	diags = gohcl.DecodeExpression(content.Attributes["control"].Expr, nil, &suppression.Control)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	diags = gohcl.DecodeExpression(content.Attributes["resource"].Expr, nil, &suppression.Resource)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	diags = gohcl.DecodeExpression(content.Attributes["justification"].Expr, nil, &suppression.Justification)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if strings.TrimSpace(suppression.Justification) == "" {
+		return nil, hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("suppression '%s' must have a justification", suppression.Name),
+			Subject:  content.Attributes["justification"].Expr.Range().Ptr(),
+		}}
+	}
+	if attr, ok := content.Attributes["dimensions"]; ok {
+		diags = gohcl.DecodeExpression(attr.Expr, nil, &suppression.Dimensions)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+	}
+
+	var expires string
+	attr := content.Attributes["expires"]
+	diags = gohcl.DecodeExpression(attr.Expr, nil, &expires)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	suppression.Expires, diags = parseSuppressionExpiry(expires, attr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return suppression, nil
+}
+
+func parseSuppressionExpiry(expires string, attr *hcl.Attribute) (time.Time, hcl.Diagnostics) {
+	for _, format := range suppressionExpiryFormats {
+		if t, err := time.Parse(format, expires); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, hcl.Diagnostics{&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("invalid suppression expiry '%s' - expected a date (YYYY-MM-DD) or an RFC3339 timestamp", expires),
+		Subject:  attr.Expr.Range().Ptr(),
+	}}
+}
@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/webhooks"
+)
+
+func DecodeWebhook(block *hcl.Block) (*modconfig.Webhook, hcl.Diagnostics) {
+	webhookContent, diags := block.Body.Content(WebhookBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	webhook := modconfig.NewWebhook(block)
+
+	urlAttr := webhookContent.Attributes["url"]
+	diags = gohcl.DecodeExpression(urlAttr.Expr, nil, &webhook.Url)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if parsedUrl, err := url.Parse(webhook.Url); err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("invalid url '%s' for webhook '%s' - must be an http or https url", webhook.Url, webhook.Name),
+			Subject:  &urlAttr.Range,
+		})
+	}
+
+	if eventsAttr, ok := webhookContent.Attributes["events"]; ok {
+		moreDiags := gohcl.DecodeExpression(eventsAttr.Expr, nil, &webhook.Events)
+		diags = append(diags, moreDiags...)
+		for _, event := range webhook.Events {
+			if !helpers.StringSliceContains(webhooks.EventTypes, event) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("invalid event '%s' for webhook '%s'", event, webhook.Name),
+					Subject:  &eventsAttr.Range,
+				})
+			}
+		}
+	}
+
+	if headersAttr, ok := webhookContent.Attributes["headers"]; ok {
+		moreDiags := gohcl.DecodeExpression(headersAttr.Expr, nil, &webhook.Headers)
+		diags = append(diags, moreDiags...)
+	}
+
+	if bodyAttr, ok := webhookContent.Attributes["body"]; ok {
+		var body string
+		moreDiags := gohcl.DecodeExpression(bodyAttr.Expr, nil, &body)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			if _, err := webhooks.ParseBodyTemplate(webhook.Name, body); err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  err.Error(),
+					Subject:  &bodyAttr.Range,
+				})
+			}
+			webhook.Body = &body
+		}
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return webhook, diags
+}
@@ -0,0 +1,54 @@
+package steampipeconfig
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/steampipeconfig/options"
+)
+
+type getPluginOptionsTest struct {
+	defaultOptions    *options.Plugin
+	connectionOptions *options.Plugin
+	expected          string
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var testCasesGetPluginOptions = map[string]getPluginOptionsTest{
+	"no options": {
+		expected: (&options.Plugin{}).String(),
+	},
+	"default options": {
+		defaultOptions: &options.Plugin{MemoryMaxMb: intPtr(1024), IdleTimeout: intPtr(600)},
+		expected:       (&options.Plugin{MemoryMaxMb: intPtr(1024), IdleTimeout: intPtr(600)}).String(),
+	},
+	"connection options": {
+		connectionOptions: &options.Plugin{MemoryMaxMb: intPtr(512)},
+		expected:          (&options.Plugin{MemoryMaxMb: intPtr(512)}).String(),
+	},
+	"connection overrides default": {
+		defaultOptions:    &options.Plugin{MemoryMaxMb: intPtr(1024), IdleTimeout: intPtr(600)},
+		connectionOptions: &options.Plugin{IdleTimeout: intPtr(0)},
+		expected:          (&options.Plugin{MemoryMaxMb: intPtr(1024), IdleTimeout: intPtr(0)}).String(),
+	},
+}
+
+func TestGetPluginOptions(t *testing.T) {
+	for name, test := range testCasesGetPluginOptions {
+		config := NewSteampipeConfig("")
+		config.DefaultPluginOptions = test.defaultOptions
+		config.Connections["a"] = &modconfig.Connection{Name: "a", PluginOptions: test.connectionOptions}
+
+		res := config.GetPluginOptions("a").String()
+		if res != test.expected {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %s, \ngot:\n %s\n", name, test.expected, res)
+		}
+		// the default options must not be modified
+		if test.defaultOptions != nil && config.DefaultPluginOptions != test.defaultOptions {
+			t.Errorf("Test: '%s'' FAILED : default options were modified", name)
+		}
+	}
+}
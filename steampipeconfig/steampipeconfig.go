@@ -21,18 +21,26 @@ import (
 type SteampipeConfig struct {
 	// map of connection name to partially parsed connection config
 	Connections map[string]*modconfig.Connection
+	// map of schedule name to schedule - these are run by the dashboard server
+	Schedules map[string]*modconfig.Schedule
+	// map of webhook name to webhook - dashboard and check events are sent to these
+	Webhooks map[string]*modconfig.Webhook
 
 	// Steampipe options
 	DefaultConnectionOptions *options.Connection
 	DatabaseOptions          *options.Database
+	DashboardOptions         *options.Dashboard
 	TerminalOptions          *options.Terminal
 	GeneralOptions           *options.General
+	DefaultPluginOptions     *options.Plugin
 	commandName              string
 }
 
 func NewSteampipeConfig(commandName string) *SteampipeConfig {
 	return &SteampipeConfig{
 		Connections: make(map[string]*modconfig.Connection),
+		Schedules:   make(map[string]*modconfig.Schedule),
+		Webhooks:    make(map[string]*modconfig.Webhook),
 		commandName: commandName,
 	}
 }
@@ -72,6 +80,9 @@ func (c *SteampipeConfig) ConfigMap() map[string]interface{} {
 	if c.TerminalOptions != nil {
 		c.populateConfigMapForOptions(c.TerminalOptions, res)
 	}
+	if c.DashboardOptions != nil {
+		c.populateConfigMapForOptions(c.DashboardOptions, res)
+	}
 
 	return res
 }
@@ -107,6 +118,12 @@ func (c *SteampipeConfig) SetOptions(opts options.Options) {
 		} else {
 			c.DatabaseOptions.Merge(o)
 		}
+	case *options.Dashboard:
+		if c.DashboardOptions == nil {
+			c.DashboardOptions = o
+		} else {
+			c.DashboardOptions.Merge(o)
+		}
 	case *options.Terminal:
 		// NOTE: do not load terminal options for check command
 		// this is a short term workaround to handle the clashing 'output' argument
@@ -125,6 +142,12 @@ func (c *SteampipeConfig) SetOptions(opts options.Options) {
 		} else {
 			c.GeneralOptions.Merge(o)
 		}
+	case *options.Plugin:
+		if c.DefaultPluginOptions == nil {
+			c.DefaultPluginOptions = o
+		} else {
+			c.DefaultPluginOptions.Merge(o)
+		}
 	}
 }
 
@@ -167,6 +190,34 @@ func (c *SteampipeConfig) setDefaultConnectionOptions() {
 	}
 }
 
+// idle eviction is disabled by default - a plugin with no recent queries may still be attached to an FDW session
+var defaultPluginIdleTimeout = 0
+
+// if the plugin idle timeout has not been set, set it to the default
+// (there is no default memory limit)
+func (c *SteampipeConfig) setDefaultPluginOptions() {
+	if c.DefaultPluginOptions == nil {
+		c.DefaultPluginOptions = &options.Plugin{}
+	}
+	if c.DefaultPluginOptions.IdleTimeout == nil {
+		c.DefaultPluginOptions.IdleTimeout = &defaultPluginIdleTimeout
+	}
+}
+
+// GetPluginOptions returns the plugin options for the connection - the default plugin options,
+// overridden by any plugin options set in the connection block
+func (c *SteampipeConfig) GetPluginOptions(connectionName string) *options.Plugin {
+	// create a copy of the options to return
+	result := &options.Plugin{}
+	if c.DefaultPluginOptions != nil {
+		result.Merge(c.DefaultPluginOptions)
+	}
+	if connection, ok := c.Connections[connectionName]; ok && connection.PluginOptions != nil {
+		result.Merge(connection.PluginOptions)
+	}
+	return result
+}
+
 func (c *SteampipeConfig) GetConnectionOptions(connectionName string) *options.Connection {
 	log.Printf("[TRACE] GetConnectionOptions")
 	connection, ok := c.Connections[connectionName]
@@ -217,6 +268,12 @@ DefaultConnectionOptions:
 
 DatabaseOptions:
 %s`, c.DatabaseOptions.String())
+	}
+	if c.DashboardOptions != nil {
+		str += fmt.Sprintf(`
+
+DashboardOptions:
+%s`, c.DashboardOptions.String())
 	}
 	if c.TerminalOptions != nil {
 		str += fmt.Sprintf(`
@@ -230,6 +287,12 @@ TerminalOptions:
 GeneralOptions:
 %s`, c.GeneralOptions.String())
 	}
+	if c.DefaultPluginOptions != nil {
+		str += fmt.Sprintf(`
+
+DefaultPluginOptions:
+%s`, c.DefaultPluginOptions.String())
+	}
 
 	return str
 }
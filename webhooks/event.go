@@ -0,0 +1,56 @@
+package webhooks
+
+import "time"
+
+// event types
+const (
+	EventDashboardComplete = "dashboard_complete"
+	EventDashboardError    = "dashboard_error"
+	EventLeafError         = "leaf_error"
+	EventCheckComplete     = "check_complete"
+	EventCheckError        = "check_error"
+)
+
+var EventTypes = []string{
+	EventDashboardComplete,
+	EventDashboardError,
+	EventLeafError,
+	EventCheckComplete,
+	EventCheckError,
+}
+
+// Event is the payload sent to a webhook
+// if the webhook has a body template, this is the data passed to the template
+type Event struct {
+	Type string `json:"type"`
+	// the name of the dashboard, dashboard node or benchmark
+	Name   string    `json:"name"`
+	Status string    `json:"status,omitempty"`
+	Error  string    `json:"error,omitempty"`
+	Time   time.Time `json:"time"`
+	// for check events, the control counts
+	Summary map[string]int `json:"summary,omitempty"`
+}
+
+func NewEvent(eventType, name string) *Event {
+	return &Event{
+		Type: eventType,
+		Name: name,
+		Time: time.Now(),
+	}
+}
+
+// NewCheckEvent returns the event sent when the execution of a benchmark or control is done
+// summary contains the control status counts, keyed by status
+// the event type is check_error if the execution failed, or if any controls are in alarm or error
+func NewCheckEvent(name, status, errorString string, summary map[string]int) *Event {
+	eventType := EventCheckComplete
+	if status == "error" || errorString != "" || summary["alarm"] > 0 || summary["error"] > 0 {
+		eventType = EventCheckError
+	}
+	event := NewEvent(eventType, name)
+	event.Status = status
+	event.Error = errorString
+	event.Summary = summary
+	return event
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import "testing"
+
+type checkEventTest struct {
+	status       string
+	errorString  string
+	summary      map[string]int
+	expectedType string
+}
+
+var testCasesCheckEvent = map[string]checkEventTest{
+	"all ok": {
+		status:       "complete",
+		summary:      map[string]int{"alarm": 0, "ok": 3, "info": 1, "skip": 1, "error": 0},
+		expectedType: EventCheckComplete,
+	},
+	"alarms": {
+		status:       "complete",
+		summary:      map[string]int{"alarm": 2, "ok": 3, "info": 0, "skip": 0, "error": 0},
+		expectedType: EventCheckError,
+	},
+	"control errors": {
+		status:       "complete",
+		summary:      map[string]int{"alarm": 0, "ok": 3, "info": 0, "skip": 0, "error": 1},
+		expectedType: EventCheckError,
+	},
+	"execution error": {
+		status:       "error",
+		errorString:  "context canceled",
+		expectedType: EventCheckError,
+	},
+}
+
+func TestNewCheckEvent(t *testing.T) {
+	for name, test := range testCasesCheckEvent {
+		event := NewCheckEvent("m1.benchmark.b1", test.status, test.errorString, test.summary)
+		if event.Type != test.expectedType {
+			t.Errorf("Test: '%s'' FAILED : expected event type %s, got %s", name, test.expectedType, event.Type)
+		}
+		if event.Status != test.status || event.Error != test.errorString {
+			t.Errorf("Test: '%s'' FAILED : expected status '%s' and error '%s', got '%s' and '%s'", name, test.status, test.errorString, event.Status, event.Error)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Notifier sends events to the configured webhooks
+type Notifier struct {
+	webhooks []*modconfig.Webhook
+	// the parsed body templates, keyed by webhook name
+	templates map[string]*template.Template
+	client    *http.Client
+}
+
+func NewNotifier(webhooks map[string]*modconfig.Webhook) (*Notifier, error) {
+	n := &Notifier{
+		templates: make(map[string]*template.Template),
+		client:    &http.Client{Timeout: requestTimeout},
+	}
+	for _, webhook := range webhooks {
+		if webhook.Body != nil {
+			bodyTemplate, err := ParseBodyTemplate(webhook.Name, *webhook.Body)
+			if err != nil {
+				return nil, err
+			}
+			n.templates[webhook.Name] = bodyTemplate
+		}
+		n.webhooks = append(n.webhooks, webhook)
+	}
+	// send in a consistent order
+	sort.Slice(n.webhooks, func(i, j int) bool {
+		return n.webhooks[i].Name < n.webhooks[j].Name
+	})
+	return n, nil
+}
+
+// ParseBodyTemplate parses a webhook body template
+// as well as the standard template functions, 'json' may be used to JSON encode a value
+func ParseBodyTemplate(name, body string) (*template.Template, error) {
+	bodyTemplate, err := template.New(name).Funcs(template.FuncMap{"json": toJson}).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body template for webhook '%s': %s", name, err.Error())
+	}
+	return bodyTemplate, nil
+}
+
+func toJson(value interface{}) (string, error) {
+	res, err := json.Marshal(value)
+	return string(res), err
+}
+
+// Notify sends the event to all webhooks which are subscribed to the event type
+// this blocks until all requests have completed - errors are logged and returned
+func (n *Notifier) Notify(ctx context.Context, event *Event) []error {
+	if n == nil {
+		return nil
+	}
+	var errors []error
+	for _, webhook := range n.webhooks {
+		if len(webhook.Events) > 0 && !helpers.StringSliceContains(webhook.Events, event.Type) {
+			continue
+		}
+		if err := n.send(ctx, webhook, event); err != nil {
+			log.Printf("[WARN] failed to send %s event to webhook %s: %s", event.Type, webhook.Name, err.Error())
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
+
+func (n *Notifier) send(ctx context.Context, webhook *modconfig.Webhook, event *Event) error {
+	body, err := n.buildBody(webhook, event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range webhook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook '%s' returned status %s", webhook.Name, resp.Status)
+	}
+	return nil
+}
+
+func (n *Notifier) buildBody(webhook *modconfig.Webhook, event *Event) ([]byte, error) {
+	bodyTemplate, ok := n.templates[webhook.Name]
+	if !ok {
+		return json.Marshal(event)
+	}
+	var body bytes.Buffer
+	if err := bodyTemplate.Execute(&body, event); err != nil {
+		return nil, fmt.Errorf("failed to render body template for webhook '%s': %s", webhook.Name, err.Error())
+	}
+	return body.Bytes(), nil
+}
@@ -0,0 +1,103 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type notifyTest struct {
+	webhook *modconfig.Webhook
+	// the status returned by the test server
+	status int
+	// the expected request body, or nil if no request is expected
+	expectedBody interface{}
+	expectedErr  bool
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+var testTime = time.Date(2022, 5, 1, 2, 0, 0, 0, time.UTC)
+
+var testCasesNotify = map[string]notifyTest{
+	"default body": {
+		webhook:      &modconfig.Webhook{},
+		status:       http.StatusOK,
+		expectedBody: map[string]interface{}{"type": "dashboard_error", "name": "m1.dashboard.d1", "status": "error", "error": "query failed", "time": "2022-05-01T02:00:00Z"},
+	},
+	"subscribed event": {
+		webhook:      &modconfig.Webhook{Events: []string{EventDashboardError}},
+		status:       http.StatusNoContent,
+		expectedBody: map[string]interface{}{"type": "dashboard_error", "name": "m1.dashboard.d1", "status": "error", "error": "query failed", "time": "2022-05-01T02:00:00Z"},
+	},
+	"unsubscribed event": {
+		webhook:      &modconfig.Webhook{Events: []string{EventCheckError}},
+		status:       http.StatusOK,
+		expectedBody: nil,
+	},
+	"body template": {
+		webhook:      &modconfig.Webhook{Body: stringPtr(`{"text": {{ printf "%s failed: %s" .Name .Error | json }}}`)},
+		status:       http.StatusOK,
+		expectedBody: map[string]interface{}{"text": "m1.dashboard.d1 failed: query failed"},
+	},
+	"error status": {
+		webhook:      &modconfig.Webhook{},
+		status:       http.StatusInternalServerError,
+		expectedBody: map[string]interface{}{"type": "dashboard_error", "name": "m1.dashboard.d1", "status": "error", "error": "query failed", "time": "2022-05-01T02:00:00Z"},
+		expectedErr:  true,
+	},
+}
+
+func TestNotify(t *testing.T) {
+	event := &Event{
+		Type:   EventDashboardError,
+		Name:   "m1.dashboard.d1",
+		Status: "error",
+		Error:  "query failed",
+		Time:   testTime,
+	}
+
+	for name, test := range testCasesNotify {
+		var body interface{}
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			requestBody, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(requestBody, &body); err != nil {
+				t.Errorf("Test: '%s'' FAILED : request body is not valid JSON: %s", name, string(requestBody))
+			}
+			w.WriteHeader(test.status)
+		}))
+
+		test.webhook.Name = "test"
+		test.webhook.Url = server.URL
+		test.webhook.Headers = map[string]string{"Authorization": "Bearer token"}
+		notifier, err := NewNotifier(map[string]*modconfig.Webhook{"test": test.webhook})
+		if err != nil {
+			t.Fatalf("Test: '%s'' FAILED : failed to create notifier: %s", name, err.Error())
+		}
+		errors := notifier.Notify(context.Background(), event)
+		server.Close()
+
+		if test.expectedErr != (len(errors) > 0) {
+			t.Errorf("Test: '%s'' FAILED : expected error %v, got %v", name, test.expectedErr, errors)
+		}
+		if !reflect.DeepEqual(test.expectedBody, body) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expectedBody, body)
+		}
+		if body != nil {
+			if header.Get("Authorization") != "Bearer token" || header.Get("Content-Type") != "application/json" {
+				t.Errorf("Test: '%s'' FAILED : unexpected headers %v", name, header)
+			}
+		}
+	}
+}
@@ -30,6 +30,8 @@ type Workspace struct {
 
 	Mods      map[string]*modconfig.Mod
 	Variables map[string]*modconfig.Variable
+	// suppressions loaded from the .spsuppress files in the workspace folder
+	Suppressions []*modconfig.Suppression
 
 	watcher    *utils.FileWatcher
 	loadLock   sync.Mutex
@@ -73,6 +75,11 @@ func Load(ctx context.Context, workspacePath string) (*Workspace, error) {
 		return nil, err
 	}
 
+	// load any control result suppressions
+	if err := workspace.loadSuppressions(); err != nil {
+		return nil, err
+	}
+
 	// return context error so calling code can handle cancellations
 	return workspace, nil
 }
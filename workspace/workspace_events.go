@@ -40,6 +40,8 @@ func (w *Workspace) handleFileWatcherEvent(ctx context.Context, client db_common
 				w.onFileWatcherEventMessages()
 			}
 		}
+		// the prepared statements have been recreated - notify handlers so any cached query results are discarded
+		w.PublishDashboardEvent(&dashboardevents.WorkspaceChanged{})
 	}
 	w.raiseDashboardChangedEvents(resourceMaps, prevResourceMaps)
 }
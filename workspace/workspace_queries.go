@@ -5,8 +5,11 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/spf13/viper"
 	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/steampipeconfig/parse"
 	"github.com/turbot/steampipe/utils"
@@ -15,24 +18,42 @@ import (
 // GetQueriesFromArgs retrieves queries from args
 //
 // For each arg check if it is a named query or a file, before falling back to treating it as sql
-func (w *Workspace) GetQueriesFromArgs(args []string) ([]string, *modconfig.WorkspaceResourceMaps, error) {
+func (w *Workspace) GetQueriesFromArgs(args []string) ([]*modconfig.ResolvedQuery, *modconfig.WorkspaceResourceMaps, error) {
 	utils.LogTime("execute.GetQueriesFromArgs start")
 	defer utils.LogTime("execute.GetQueriesFromArgs end")
 
-	var queries []string
+	// parse any args passed using --arg
+	commandLineArgs, err := parse.ParseCommandLineArgs(viper.GetStringSlice(constants.ArgArg))
+	if err != nil {
+		return nil, nil, err
+	}
+	// keep track of which command line args have been bound to a query param
+	boundArgs := make(map[string]bool)
+
+	var queries []*modconfig.ResolvedQuery
 	var queryProviders []modconfig.QueryProvider
 	// build map of just the required prepared statement providers
 	for _, arg := range args {
-		query, queryProvider, err := w.ResolveQueryAndArgs(arg)
+		query, queryProvider, err := w.resolveQueryAndArgs(arg, commandLineArgs, boundArgs)
 		if err != nil {
 			return nil, nil, err
 		}
 		if len(query) > 0 {
-			queries = append(queries, query)
+			queries = append(queries, &modconfig.ResolvedQuery{
+				Name: getResolvedQueryName(arg, query, queryProvider),
+				SQL:  query,
+			})
 			queryProviders = append(queryProviders, queryProvider)
 
 		}
 	}
+	// verify all command line args have been bound to a param
+	for name := range commandLineArgs {
+		if !boundArgs[name] {
+			return nil, nil, fmt.Errorf("--%s '%s' does not match a parameter of any of the named queries", constants.ArgArg, name)
+		}
+	}
+
 	var preparedStatementSource *modconfig.WorkspaceResourceMaps
 	if len(queries) > 0 {
 		preparedStatementSource = modconfig.CreateWorkspaceResourceMapForQueryProviders(queryProviders)
@@ -42,6 +63,12 @@ func (w *Workspace) GetQueriesFromArgs(args []string) ([]string, *modconfig.Work
 
 // ResolveQueryAndArgs attempts to resolve 'arg' to a query and query args
 func (w *Workspace) ResolveQueryAndArgs(sqlString string) (string, modconfig.QueryProvider, error) {
+	return w.resolveQueryAndArgs(sqlString, nil, nil)
+}
+
+// resolveQueryAndArgs attempts to resolve 'arg' to a query and query args,
+// binding any command line args to the params of the named query or control
+func (w *Workspace) resolveQueryAndArgs(sqlString string, commandLineArgs map[string]string, boundArgs map[string]bool) (string, modconfig.QueryProvider, error) {
 	var args = &modconfig.QueryArgs{}
 
 	var err error
@@ -52,6 +79,11 @@ func (w *Workspace) ResolveQueryAndArgs(sqlString string) (string, modconfig.Que
 		if err != nil {
 			return "", nil, err
 		}
+		// bind any command line args and verify all required params have a value
+		args, err = w.bindCommandLineArgs(sqlString, args, commandLineArgs, boundArgs)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
 	return w.resolveQuery(sqlString, args)
@@ -194,6 +226,74 @@ func (w *Workspace) resolveQueryProviderArgs(queryProvider modconfig.QueryProvid
 	return args, nil
 }
 
+// bindCommandLineArgs binds args passed using --arg to the params of the named query or control,
+// merging them with any args passed in the query invocation (command line args take precedence)
+// and returns an error listing any params which have no value and no default
+func (w *Workspace) bindCommandLineArgs(name string, args *modconfig.QueryArgs, commandLineArgs map[string]string, boundArgs map[string]bool) (*modconfig.QueryArgs, error) {
+	var queryProvider modconfig.QueryProvider
+	if control, ok := w.GetControl(name); ok {
+		queryProvider = control
+	} else if query, ok := w.GetQuery(name); ok {
+		queryProvider = query
+	} else {
+		// resolveQuery will report that the resource was not found
+		return args, nil
+	}
+
+	params := queryProvider.GetParams()
+	if len(params) == 0 {
+		return args, nil
+	}
+	// if no args are passed, any args defined by the query provider are used instead
+	if args.Empty() && len(commandLineArgs) == 0 {
+		if providerArgs := queryProvider.GetArgs(); providerArgs != nil && !providerArgs.Empty() {
+			return args, nil
+		}
+	}
+	if len(args.ArgsList) > 0 {
+		if len(commandLineArgs) > 0 {
+			return nil, fmt.Errorf("%s: positional args cannot be combined with --%s", name, constants.ArgArg)
+		}
+		// positional args are validated when the prepared statement execute sql is built
+		return args, nil
+	}
+
+	res := modconfig.NewQueryArgs()
+	for k, v := range args.Args {
+		res.Args[k] = v
+	}
+
+	var missingParams []string
+	for _, param := range params {
+		if value, ok := commandLineArgs[param.Name]; ok {
+			pgValue, err := parse.ConvertCommandLineArg(value, param)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			res.Args[param.Name] = pgValue
+			boundArgs[param.Name] = true
+		} else if _, ok := res.Args[param.Name]; !ok && typehelpers.SafeString(param.Default) == "" {
+			// no value provided and no default defined - add to missing list
+			missingParams = append(missingParams, param.Name)
+		}
+	}
+
+	if len(missingParams) > 0 {
+		// command line args are only available when running queries passed as command args
+		hint := fmt.Sprintf("pass using --%s name=value", constants.ArgArg)
+		if commandLineArgs == nil {
+			hint = fmt.Sprintf("pass using %s(name => value)", name)
+		}
+		return nil, fmt.Errorf("%s requires values for %d %s with no default: %s (%s)",
+			name,
+			len(missingParams),
+			utils.Pluralize("parameter", len(missingParams)),
+			strings.Join(missingParams, ", "),
+			hint)
+	}
+	return res, nil
+}
+
 func (w *Workspace) getQueryFromFile(filename string) (string, bool, error) {
 	// get absolute filename
 	path, err := filepath.Abs(filename)
@@ -215,6 +315,19 @@ func (w *Workspace) getQueryFromFile(filename string) (string, bool, error) {
 	return string(fileBytes), true, nil
 }
 
+// return the name of the source of a resolved query - a query provider or a file
+// (raw SQL is returned unchanged by ResolveQueryAndArgs, so if there is no query provider and the query
+// differs from the arg, the arg must have been a file)
+func getResolvedQueryName(arg, query string, queryProvider modconfig.QueryProvider) string {
+	if queryProvider != nil {
+		return queryProvider.Name()
+	}
+	if query != arg {
+		return strings.TrimSuffix(filepath.Base(arg), filepath.Ext(arg))
+	}
+	return ""
+}
+
 // does this resource name look like a control or query
 func isNamedQueryOrControl(name string) bool {
 	parsedResourceName, err := modconfig.ParseResourceName(name)
@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/steampipeconfig/parse"
+	"github.com/turbot/steampipe/utils"
+)
+
+type bindCommandLineArgsTest struct {
+	invocation      string
+	commandLineArgs map[string]string
+	expected        interface{}
+}
+
+var testCasesBindCommandLineArgs = map[string]bindCommandLineArgsTest{
+	"no params": {
+		invocation:      "query.no_params",
+		commandLineArgs: map[string]string{},
+		expected:        map[string]string{},
+	},
+	"missing required param": {
+		invocation:      "query.q1",
+		commandLineArgs: map[string]string{},
+		expected:        "ERROR",
+	},
+	"missing required param in interactive query": {
+		invocation: "query.q1",
+		expected:   "ERROR",
+	},
+	"command line arg": {
+		invocation:      "query.q1",
+		commandLineArgs: map[string]string{"p1": "foo"},
+		expected:        map[string]string{"p1": "'foo'"},
+	},
+	"named invocation arg": {
+		invocation:      `query.q1(p1 => "foo")`,
+		commandLineArgs: map[string]string{},
+		expected:        map[string]string{"p1": "'foo'"},
+	},
+	"command line arg overrides invocation arg": {
+		invocation:      `query.q1(p1 => "foo")`,
+		commandLineArgs: map[string]string{"p1": "bar"},
+		expected:        map[string]string{"p1": "'bar'"},
+	},
+	"positional invocation args": {
+		invocation:      `query.q1("foo")`,
+		commandLineArgs: map[string]string{},
+		expected:        map[string]string{},
+	},
+	"positional invocation args with command line arg": {
+		invocation:      `query.q1("foo")`,
+		commandLineArgs: map[string]string{"p1": "bar"},
+		expected:        "ERROR",
+	},
+}
+
+func TestBindCommandLineArgs(t *testing.T) {
+	w := &Workspace{
+		resourceMaps: &modconfig.WorkspaceResourceMaps{
+			Queries: map[string]*modconfig.Query{
+				"query.no_params": {FullName: "query.no_params"},
+				"query.q1": {
+					FullName: "query.q1",
+					Params: []*modconfig.ParamDef{
+						{Name: "p1"},
+						{Name: "p2", Default: utils.ToStringPointer("'default'")},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range testCasesBindCommandLineArgs {
+		queryName, args, err := parse.ParsePreparedStatementInvocation(test.invocation)
+		if err != nil {
+			t.Fatalf("Test: '%s'' FAILED to parse invocation: %v", name, err)
+		}
+		res, err := w.bindCommandLineArgs(queryName, args, test.commandLineArgs, map[string]bool{})
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		expected := test.expected.(map[string]string)
+		if len(res.Args) != len(expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, expected, res.Args)
+			continue
+		}
+		for k, v := range expected {
+			if res.Args[k] != v {
+				t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, expected, res.Args)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package workspace
+
+import (
+	"time"
+
+	filehelpers "github.com/turbot/go-kit/files"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/steampipeconfig/parse"
+)
+
+// load the suppression blocks from all .spsuppress files in the top level of the workspace folder
+func (w *Workspace) loadSuppressions() error {
+	suppressionPaths, err := filehelpers.ListFiles(w.Path, &filehelpers.ListOptions{
+		Flags:   filehelpers.FilesFlat,
+		Include: filehelpers.InclusionsFromExtensions([]string{constants.SuppressionsExtension}),
+	})
+	if err != nil {
+		return err
+	}
+	w.Suppressions, err = parse.ParseSuppressions(suppressionPaths...)
+	return err
+}
+
+// GetActiveSuppressions returns the suppressions which have not expired at the given time
+func (w *Workspace) GetActiveSuppressions(now time.Time) []*modconfig.Suppression {
+	var res []*modconfig.Suppression
+	for _, suppression := range w.Suppressions {
+		if !suppression.Expired(now) {
+			res = append(res, suppression)
+		}
+	}
+	return res
+}
+
+// GetExpiredSuppressions returns the suppressions which have expired at the given time
+func (w *Workspace) GetExpiredSuppressions(now time.Time) []*modconfig.Suppression {
+	var res []*modconfig.Suppression
+	for _, suppression := range w.Suppressions {
+		if suppression.Expired(now) {
+			res = append(res, suppression)
+		}
+	}
+	return res
+}